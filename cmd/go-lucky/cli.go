@@ -0,0 +1,629 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// ErrUsage indicates invalid command-line usage
+var ErrUsage = errors.New("usage error")
+
+const (
+	// Process exit codes
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	// programName is shown in usage output
+	programName = "go-lucky"
+
+	// defaultCommand runs when no subcommand is given
+	defaultCommand = "analyze"
+
+	// defaultServeAddr is the listen address for the serve subcommand
+	defaultServeAddr = "127.0.0.1:8080"
+)
+
+// cliOptions holds the values bound to a subcommand's flags and its I/O streams
+type cliOptions struct {
+	config   *lucky.AnalysisConfig
+	dataPath string
+	mode     string
+	format   string
+	output   string
+	outFile  string
+	count    int
+	addr     string
+	backtest lucky.BacktestConfig
+	tickets  string
+	ledger   ledgerOptions
+	wheel    wheelOptions
+	bankroll lucky.BankrollConfig
+
+	constraints    constraintOptions
+	popularityFile string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command describes a CLI subcommand
+type command struct {
+	name    string
+	args    string // Positional argument synopsis shown in usage
+	summary string
+	flags   func(fs *flag.FlagSet, opts *cliOptions)
+	run     func(ctx context.Context, opts *cliOptions, args []string) error
+}
+
+// commands returns the available subcommands in display order
+func commands() []*command {
+	return []*command{
+		{
+			name:    "analyze",
+			summary: "Run the frequency, pattern and cosmic analysis report",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.mode, "mode", lucky.OutputModeDetailed, "report mode: detailed|simple|statistical|cosmic")
+				addOutputFlag(fs, opts)
+			},
+			run: runAnalyze,
+		},
+		{
+			name:    "recommend",
+			summary: "Generate recommended number sets",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.IntVar(&opts.count, "count", 5, "number of sets to generate, cycling through the strategies")
+				addStrategyFlag(fs, opts)
+				addConstraintFlags(fs, opts)
+				fs.Float64Var(&opts.config.Temperature, "temperature", lucky.DefaultSamplingTemperature,
+					"randomness of the picks: 0 takes each strategy's top numbers, higher values spread picks more evenly")
+				fs.Int64Var(&opts.config.Seed, "seed", 0, "seed for repeatable picks (default a random seed, printed to stderr)")
+				addOutputFlag(fs, opts)
+			},
+			run: runRecommend,
+		},
+		{
+			name:    "wheel",
+			args:    "[<number>...]",
+			summary: "Build a full, abbreviated or key number wheel over a pool of numbers",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.wheel.kind, "type", lucky.WheelAbbreviated,
+					fmt.Sprintf("wheel type: %s|%s|%s", lucky.WheelAbbreviated, lucky.WheelFull, lucky.WheelKey))
+				fs.StringVar(&opts.wheel.poolFrom, "pool", lucky.WheelPoolTop,
+					fmt.Sprintf("numbers to wheel when none are given: %s|%s|%s", lucky.WheelPoolTop, lucky.WheelPoolRecent, lucky.WheelPoolOverdue))
+				fs.IntVar(&opts.wheel.size, "size", defaultWheelPoolSize, "how many --pool numbers to wheel")
+				fs.StringVar(&opts.wheel.keys, "keys", "", "comma-separated key numbers played on every ticket (key wheel)")
+				fs.StringVar(&opts.wheel.guarantee, "guarantee", "", "k-if-m coverage, e.g. 3-if-4 (default 3-if-4 abbreviated, all numbers full)")
+				fs.IntVar(&opts.wheel.luckyBall, "lucky-ball", 0, "bonus ball on every ticket (default the first --strategy's pick)")
+				addStrategyFlag(fs, opts)
+				addOutputFlag(fs, opts)
+			},
+			run: runWheel,
+		},
+		{
+			name:    "ev",
+			summary: "Show a ticket's exact odds, expected return and ROI, and simulate a bankroll",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.IntVar(&opts.bankroll.TicketsPerWeek, "tickets-per-week", defaultBankrollTicketsPerWeek, "tickets bought each simulated week")
+				fs.IntVar(&opts.bankroll.Years, "years", defaultBankrollYears, "years of weekly play to simulate")
+				fs.Int64Var(&opts.bankroll.Seed, "seed", 0, "seed for a repeatable simulation (default a random seed, printed to stderr)")
+				addOutputFlag(fs, opts)
+			},
+			run: runEV,
+		},
+		{
+			name:    "export",
+			summary: "Export the analysis to a JSON or CSV file",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.format, "format", lucky.ExportFormatJSON, "export format: json|csv")
+				fs.StringVar(&opts.outFile, "out", "", "output file for json; directory or .zip bundle for csv (default lottery_analysis_<timestamp>)")
+			},
+			run: runExport,
+		},
+		{
+			name:    "check",
+			args:    "<ticket>...",
+			summary: "Check tickets against the full drawing history",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.tickets, "tickets", "", "CSV file with one ticket per row")
+				addOutputFlag(fs, opts)
+			},
+			run: runCheck,
+		},
+		{
+			name:    "ledger",
+			args:    "add|list|reconcile [<ticket>...]",
+			summary: "Record tickets bought and reconcile them against new drawings",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.ledger.path, "ledger", "",
+					fmt.Sprintf("ledger file (default: $%s, then $XDG_DATA_HOME/%s/%s)", envLedgerPath, appDataDir, ledgerFileName))
+				fs.StringVar(&opts.ledger.owner, "owner", "", "who paid for the tickets (required by add)")
+				fs.StringVar(&opts.ledger.purchased, "purchased", "", "purchase date, YYYY-MM-DD (default today)")
+				fs.StringVar(&opts.ledger.firstDraw, "first", "", "first drawing covered, YYYY-MM-DD (default the purchase date)")
+				fs.StringVar(&opts.ledger.lastDraw, "last", "", "last drawing covered, YYYY-MM-DD (default the first drawing)")
+				fs.Float64Var(&opts.ledger.cost, "cost", 0, "amount paid per ticket (default the ticket price times the drawings covered)")
+				fs.StringVar(&opts.tickets, "tickets", "", "CSV file with one ticket per row to add")
+				addOutputFlag(fs, opts)
+			},
+			run: runLedger,
+		},
+		{
+			name:    "backtest",
+			summary: "Walk-forward backtest of the recommendation strategies",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.IntVar(&opts.backtest.MinHistory, "min-history", lucky.DefaultBacktestMinHistory,
+					"earlier drawings required before a drawing is tested")
+				fs.IntVar(&opts.backtest.MaxTests, "last", 0, "test only the most recent N drawings (default all)")
+				addStrategyFlag(fs, opts)
+				addConstraintFlags(fs, opts)
+				addOutputFlag(fs, opts)
+			},
+			run: runBacktest,
+		},
+		{
+			name:    "cosmic",
+			summary: "Show the cosmic correlation report and cosmic pick",
+			flags:   addOutputFlag,
+			run:     runCosmic,
+		},
+		{
+			name:    "serve",
+			summary: "Serve the analysis as a JSON HTTP API",
+			flags: func(fs *flag.FlagSet, opts *cliOptions) {
+				fs.StringVar(&opts.addr, "addr", defaultServeAddr, "listen address")
+			},
+			run: runServe,
+		},
+	}
+}
+
+// findCommand returns the subcommand with the given name
+func findCommand(name string) *command {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// run executes the CLI with the given arguments (excluding the program name) and returns the exit code
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	// No subcommand (or flags only) runs the default analysis
+	name := defaultCommand
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	} else if len(args) > 0 && isHelpFlag(args[0]) {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	if name == "help" {
+		if len(args) == 0 {
+			printUsage(stdout)
+			return exitOK
+		}
+		name, args = args[0], []string{"--help"}
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		_, _ = fmt.Fprintf(stderr, "Error: unknown command %q\n\n", name)
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	return cmd.execute(ctx, args, stdin, stdout, stderr)
+}
+
+// execute parses the subcommand's flags, validates them and runs it
+func (c *command) execute(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts := &cliOptions{
+		config: &lucky.AnalysisConfig{
+			MinGapMultiplier: 1.5,
+			OutputMode:       lucky.OutputModeDetailed,
+			ExportFormat:     lucky.ExportFormatConsole,
+		},
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	fs := flag.NewFlagSet(programName+" "+c.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {} // Usage is printed below so --help can go to stdout
+	addCommonFlags(fs, opts)
+	if c.flags != nil {
+		c.flags(fs, opts)
+	}
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			c.printUsage(stdout, fs)
+			return exitOK
+		}
+		c.printUsage(stderr, fs)
+		return exitUsage
+	}
+
+	if c.args == "" && len(positional) > 0 {
+		return c.usageError(stderr, fmt.Errorf("%w: unexpected argument %q", ErrUsage, positional[0]))
+	}
+	if err := validateCommonOptions(opts); err != nil {
+		return c.usageError(stderr, err)
+	}
+
+	if err = c.run(ctx, opts, positional); err != nil {
+		if errors.Is(err, ErrUsage) {
+			return c.usageError(stderr, err)
+		}
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// parseFlags parses flags given before, between or after the positional arguments and returns
+// the positional arguments; everything after a "--" terminator is positional
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// The flag package stops at the first positional argument, or consumes "--" and stops after it
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// usageError reports a usage problem and returns the usage exit code
+func (c *command) usageError(stderr io.Writer, err error) int {
+	_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+	_, _ = fmt.Fprintf(stderr, "Run '%s %s --help' for usage.\n", programName, c.name)
+	return exitUsage
+}
+
+// addCommonFlags registers the flags shared by every subcommand
+func addCommonFlags(fs *flag.FlagSet, opts *cliOptions) {
+	fs.StringVar(&opts.dataPath, "data", "",
+		fmt.Sprintf("history CSV to analyze, or - for stdin (default: $%s, then $XDG_DATA_HOME/%s, then data/)", envDataPath, appDataDir))
+	fs.StringVar(&opts.config.Game, "game", lucky.DefaultGame, "game rules to apply: "+strings.Join(lucky.GameKeys(), "|"))
+	fs.IntVar(&opts.config.RecentWindow, "recent", 50, "number of drawings considered recent")
+	fs.Float64Var(&opts.config.ConfidenceLevel, "confidence", 0.95, "statistical confidence level (0-1)")
+}
+
+// addOutputFlag registers the --output flag of commands that print results
+func addOutputFlag(fs *flag.FlagSet, opts *cliOptions) {
+	fs.StringVar(&opts.output, "output", lucky.OutputFormatText, "output format: text|json|ndjson")
+}
+
+// addStrategyFlag registers the --strategy flag of commands that generate recommended sets
+func addStrategyFlag(fs *flag.FlagSet, opts *cliOptions) {
+	fs.Func("strategy", "comma-separated recommendation strategies (default all: "+
+		strings.Join(lucky.NewStrategyRegistry().Names(), ",")+")", func(value string) error {
+		opts.config.Strategies = splitList(value)
+		return nil
+	})
+}
+
+// validateCommonOptions rejects shared flag values the analyzer would otherwise silently replace
+func validateCommonOptions(opts *cliOptions) error {
+	if opts.config.RecentWindow <= 0 {
+		return fmt.Errorf("%w: --recent must be positive, got %d", ErrUsage, opts.config.RecentWindow)
+	}
+	if opts.config.ConfidenceLevel <= 0 || opts.config.ConfidenceLevel >= 1 {
+		return fmt.Errorf("%w: --confidence must be between 0 and 1, got %g", ErrUsage, opts.config.ConfidenceLevel)
+	}
+	if _, err := lucky.LookupGameSpec(opts.config.Game); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	return nil
+}
+
+// loadAnalyzer builds an analyzer from stdin or the resolved history file
+func loadAnalyzer(ctx context.Context, opts *cliOptions) (*lucky.Analyzer, error) {
+	if opts.dataPath == stdinDataPath {
+		return lucky.NewAnalyzerFromReader(ctx, opts.stdin, opts.config)
+	}
+
+	game, err := lucky.LookupGameSpec(opts.config.Game)
+	if err != nil {
+		return nil, err
+	}
+	path, err := resolveDataPath(opts.dataPath, game)
+	if err != nil {
+		return nil, err
+	}
+	return lucky.NewAnalyzer(ctx, path, opts.config)
+}
+
+// runAnalyze prints the analysis report in the selected mode
+func runAnalyze(ctx context.Context, opts *cliOptions, _ []string) error {
+	switch opts.mode {
+	case lucky.OutputModeDetailed, lucky.OutputModeSimple, lucky.OutputModeStatistical, lucky.OutputModeCosmic:
+		opts.config.OutputMode = opts.mode
+	default:
+		return fmt.Errorf("%w: unknown --mode %q", ErrUsage, opts.mode)
+	}
+	renderer, err := lucky.NewRenderer(opts.mode, opts.output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	analyzer.SetProgressWriter(opts.stderr)
+	return analyzer.WriteReport(ctx, opts.stdout, renderer)
+}
+
+// runCosmic prints the cosmic correlation report
+func runCosmic(ctx context.Context, opts *cliOptions, args []string) error {
+	opts.mode = lucky.OutputModeCosmic
+	return runAnalyze(ctx, opts, args)
+}
+
+// runRecommend prints recommended number sets
+func runRecommend(ctx context.Context, opts *cliOptions, _ []string) error {
+	if opts.count <= 0 {
+		return fmt.Errorf("%w: --count must be positive, got %d", ErrUsage, opts.count)
+	}
+	if !(opts.config.Temperature >= 0) || math.IsInf(opts.config.Temperature, 0) {
+		return fmt.Errorf("%w: --temperature must not be negative, got %g", ErrUsage, opts.config.Temperature)
+	}
+	if opts.config.Temperature > 0 {
+		if opts.config.Seed == 0 {
+			opts.config.Seed = rand.Int64N(math.MaxInt64) + 1 //nolint:gosec // a seed for picks, not a secret
+		}
+		_, _ = fmt.Fprintf(opts.stderr, "🎲 Sampling seed: %d (repeat these picks with --seed %d)\n", opts.config.Seed, opts.config.Seed)
+	}
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	if err := resolveConstraints(opts); err != nil {
+		return err
+	}
+	if err := loadPopularityModel(opts); err != nil {
+		return err
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	recommendations, err := analyzer.GenerateRecommendations(ctx, opts.count)
+	if isGenerationUsageError(err) {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	} else if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, recommendations)
+	case lucky.OutputFormatNDJSON:
+		records := make([]lucky.Record, len(recommendations))
+		for i, rec := range recommendations {
+			records[i] = lucky.Record{Type: lucky.RecordTypeRecommendation, Data: rec}
+		}
+		return lucky.WriteRecords(opts.stdout, records)
+	}
+
+	game := analyzer.Game()
+	for i, rec := range recommendations {
+		_, _ = fmt.Fprintf(opts.stdout, "Set %d (%s): %s", i+1, rec.Strategy, formatNumbers(rec.Numbers))
+		if game.HasBonus() {
+			_, _ = fmt.Fprintf(opts.stdout, "  %s: %d", game.BonusName, rec.LuckyBall)
+		}
+		_, _ = fmt.Fprintf(opts.stdout, "\n  %s\n", rec.Explanation)
+		if rec.Value != nil {
+			_, _ = fmt.Fprintf(opts.stdout, "  %s\n", rec.Value)
+		}
+	}
+	return nil
+}
+
+// runExport writes the analysis to a file in the selected format
+func runExport(ctx context.Context, opts *cliOptions, _ []string) error {
+	switch opts.format {
+	case lucky.ExportFormatJSON, lucky.ExportFormatCSV:
+		opts.config.ExportFormat = opts.format
+	default:
+		return fmt.Errorf("%w: unknown --format %q", ErrUsage, opts.format)
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	filename := opts.outFile
+	if filename == "" {
+		// CSV exports are a directory of tables
+		filename = "lottery_analysis_" + time.Now().Format("20060102_150405")
+		if opts.format == lucky.ExportFormatJSON {
+			filename += ".json"
+		}
+	}
+	if err = analyzer.ExportAnalysis(ctx, filename); err != nil {
+		return fmt.Errorf("exporting analysis: %w", err)
+	}
+	_, _ = fmt.Fprintf(opts.stdout, "Analysis exported to: %s\n", filename)
+	return nil
+}
+
+// runBacktest replays the drawing history and scores each strategy against random picks
+func runBacktest(ctx context.Context, opts *cliOptions, _ []string) error {
+	if opts.backtest.MinHistory <= 0 {
+		return fmt.Errorf("%w: --min-history must be positive, got %d", ErrUsage, opts.backtest.MinHistory)
+	}
+	if opts.backtest.MaxTests < 0 {
+		return fmt.Errorf("%w: --last must not be negative, got %d", ErrUsage, opts.backtest.MaxTests)
+	}
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	if err := resolveConstraints(opts); err != nil {
+		return err
+	}
+	if err := loadPopularityModel(opts); err != nil {
+		return err
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	analyzer.SetProgressWriter(opts.stderr)
+	result, err := analyzer.Backtest(ctx, opts.backtest)
+	if isGenerationUsageError(err) {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	} else if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, result)
+	case lucky.OutputFormatNDJSON:
+		return lucky.WriteRecords(opts.stdout, result.Records())
+	}
+	return lucky.WriteBacktest(opts.stdout, result)
+}
+
+// runCheck plays each ticket in every drawing of the history and reports what it would have won
+func runCheck(ctx context.Context, opts *cliOptions, args []string) error {
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	game, err := lucky.LookupGameSpec(opts.config.Game)
+	if err != nil {
+		return err
+	}
+
+	tickets := make([]lucky.Ticket, 0, len(args))
+	for _, arg := range args {
+		ticket, parseErr := lucky.ParseTicket(game, arg)
+		if parseErr != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, parseErr)
+		}
+		tickets = append(tickets, ticket)
+	}
+	if opts.tickets != "" {
+		fromFile, readErr := readTicketFile(game, opts.tickets)
+		if readErr != nil {
+			return readErr
+		}
+		tickets = append(tickets, fromFile...)
+	}
+	if len(tickets) == 0 {
+		return fmt.Errorf("%w: give at least one ticket (e.g. 05-12-23-34-45+07) or --tickets", ErrUsage)
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	checks := make([]*lucky.TicketCheck, len(tickets))
+	for i, ticket := range tickets {
+		if checks[i], err = analyzer.CheckTicket(ticket); err != nil {
+			return err
+		}
+	}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, checks)
+	case lucky.OutputFormatNDJSON:
+		records := make([]lucky.Record, len(checks))
+		for i, check := range checks {
+			records[i] = lucky.Record{Type: lucky.RecordTypeTicketCheck, Data: check}
+		}
+		return lucky.WriteRecords(opts.stdout, records)
+	}
+	return lucky.WriteTicketChecks(opts.stdout, analyzer.Game(), checks)
+}
+
+// readTicketFile reads tickets from a CSV file
+func readTicketFile(game *lucky.GameSpec, path string) ([]lucky.Ticket, error) {
+	file, err := os.Open(path) // #nosec G304 - path comes from the --tickets flag
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tickets: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return lucky.ReadTickets(game, file)
+}
+
+// writeJSONDocument writes v as one indented JSON document
+func writeJSONDocument(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// formatNumbers joins numbers as zero-padded, dash-separated text (e.g. 05-12-23)
+func formatNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, num := range numbers {
+		parts[i] = fmt.Sprintf("%02d", num)
+	}
+	return strings.Join(parts, "-")
+}
+
+// isHelpFlag reports whether an argument requests help
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+// printUsage writes the top-level usage with the list of subcommands
+func printUsage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Lottery Analyzer")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "Usage: %s <command> [flags]\n", programName)
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "With no command, %s runs '%s'.\n", programName, defaultCommand)
+	_, _ = fmt.Fprintf(w, "Run '%s <command> --help' for the flags of a command.\n", programName)
+}
+
+// printUsage writes the subcommand usage generated from its flag definitions
+func (c *command) printUsage(w io.Writer, fs *flag.FlagSet) {
+	synopsis := fmt.Sprintf("%s %s [flags]", programName, c.name)
+	if c.args != "" {
+		synopsis += " " + c.args
+	}
+	_, _ = fmt.Fprintf(w, "Usage: %s\n\n%s\n\nFlags:\n", synopsis, c.summary)
+
+	previous := fs.Output()
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fs.SetOutput(previous)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// CLITestSuite defines the test suite for the command-line interface
+type CLITestSuite struct {
+	suite.Suite
+
+	analyzer *lucky.Analyzer
+	testFile string
+}
+
+// SetupSuite writes the drawing history fixture used by the CLI tests
+func (s *CLITestSuite) SetupSuite() {
+	s.testFile = "test_cli_data.csv"
+	content := `Date,Number 1,Number 2,Number 3,Number 4,Number 5,Lucky Ball
+01/15/2024,5,12,23,34,45,7
+01/12/2024,3,15,22,38,44,12
+01/09/2024,5,18,23,35,42,7
+01/06/2024,7,12,25,33,48,15
+01/03/2024,2,11,23,34,41,3`
+
+	err := os.WriteFile(s.testFile, []byte(content), 0o600)
+	s.Require().NoError(err)
+}
+
+// TearDownSuite runs once after all tests
+func (s *CLITestSuite) TearDownSuite() {
+	_ = os.Remove(s.testFile) // ignore error in cleanup
+}
+
+// SetupTest loads a fresh analyzer before each test
+func (s *CLITestSuite) SetupTest() {
+	analyzer, err := lucky.NewAnalyzer(context.Background(), s.testFile, nil)
+	s.Require().NoError(err)
+	s.analyzer = analyzer
+}
+
+// luckyForLife returns the built-in Lucky for Life specification
+func (s *CLITestSuite) luckyForLife() *lucky.GameSpec {
+	game, err := lucky.LookupGameSpec(lucky.GameLuckyForLife)
+	s.Require().NoError(err)
+	return game
+}
+
+// runCLI executes the CLI with the given arguments and captures its output
+func (s *CLITestSuite) runCLI(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestCLIHelp tests the generated top-level and subcommand help
+func (s *CLITestSuite) TestCLIHelp() {
+	code, stdout, _ := s.runCLI("", "--help")
+	s.Equal(exitOK, code)
+	for _, cmd := range commands() {
+		s.Contains(stdout, cmd.name)
+	}
+
+	// Subcommand help lists its own flags and the shared ones
+	code, stdout, _ = s.runCLI("", "export", "--help")
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "Usage: go-lucky export [flags]")
+	s.Contains(stdout, "-format")
+	s.Contains(stdout, "-recent")
+	s.NotContains(stdout, "-addr")
+
+	code, stdout, _ = s.runCLI("", "help", "serve")
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "-addr")
+}
+
+// TestCLIUsageErrors tests that invalid invocations exit with the usage code
+func (s *CLITestSuite) TestCLIUsageErrors() {
+	testCases := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{"unknown command", []string{"predict"}, "unknown command"},
+		{"unknown flag", []string{"analyze", "--unknown-flag"}, "flag provided but not defined"},
+		{"non-numeric recent", []string{"--recent", "abc"}, "invalid value"},
+		{"missing flag value", []string{"analyze", "--recent"}, "flag needs an argument"},
+		{"zero recent", []string{"analyze", "--recent", "0"}, "--recent must be positive"},
+		{"bad confidence", []string{"analyze", "--confidence", "1.5"}, "--confidence"},
+		{"unknown game", []string{"recommend", "--game", "keno"}, "unknown game"},
+		{"unknown mode", []string{"analyze", "--mode", "verbose", "--data", s.testFile}, "unknown --mode"},
+		{"unknown format", []string{"export", "--format", "xml", "--data", s.testFile}, "unknown --format"},
+		{"bad count", []string{"recommend", "--count", "0", "--data", s.testFile}, "--count must be positive"},
+		{"unknown output", []string{"analyze", "--output", "xml", "--data", s.testFile}, "unknown output format"},
+		{"unknown recommend output", []string{"recommend", "--output", "yaml", "--data", s.testFile}, "unknown output format"},
+		{"unexpected argument", []string{"cosmic", "extra"}, "unexpected argument"},
+		{"zero min history", []string{"backtest", "--min-history", "0", "--data", s.testFile}, "--min-history must be positive"},
+		{"negative last", []string{"backtest", "--last", "-1", "--data", s.testFile}, "--last must not be negative"},
+		{"negative temperature", []string{"recommend", "--temperature", "-1", "--data", s.testFile}, "--temperature must not be negative"},
+		{"unknown strategy", []string{"recommend", "--strategy", "hot,mine", "--data", s.testFile}, "unknown strategy"},
+		{"unknown backtest strategy", []string{"backtest", "--strategy", "mine", "--min-history", "2", "--data", s.testFile}, "unknown strategy"},
+		{"strategy with nothing to play", []string{"recommend", "--strategy", "pattern", "--data", s.testFile}, "nothing to play"},
+	}
+
+	for _, tc := range testCases {
+		code, _, stderr := s.runCLI("", tc.args...)
+		s.Equal(exitUsage, code, "Test case: %s", tc.name)
+		s.Contains(stderr, tc.stderr, "Test case: %s", tc.name)
+	}
+}
+
+// TestCLIAnalyze tests the analyze command and its default invocation
+func (s *CLITestSuite) TestCLIAnalyze() {
+	code, stdout, stderr := s.runCLI("", "analyze", "--mode", lucky.OutputModeSimple, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "QUICK PICKS:")
+	s.NotContains(stdout, "Fetching Cosmic Data")
+	s.Contains(stderr, "Fetching Cosmic Data")
+
+	// Flags without a subcommand run analyze
+	code, _, stderr = s.runCLI("", "--mode", lucky.OutputModeStatistical, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+
+	// Missing files are runtime errors, not usage errors
+	code, _, stderr = s.runCLI("", "analyze", "--data", "missing_history.csv")
+	s.Equal(exitError, code)
+	s.Contains(stderr, "failed to open file")
+}
+
+// TestCLIRecommend tests recommendations read from stdin
+func (s *CLITestSuite) TestCLIRecommend() {
+	content, err := os.ReadFile(s.testFile)
+	s.Require().NoError(err)
+
+	code, stdout, stderr := s.runCLI(string(content), "recommend", "--count", "2", "--data", "-")
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "Set 1 (balanced)")
+	s.Contains(stdout, "Set 2 (hot)")
+	s.Contains(stdout, "Lucky Ball:")
+
+	// Strategies cycle when more sets than strategies are requested
+	code, stdout, stderr = s.runCLI("", "recommend", "--strategy", "overdue, frequency", "--count", "3", "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "Set 1 (overdue)")
+	s.Contains(stdout, "Set 2 (frequency)")
+	s.Contains(stdout, "Set 3 (overdue)")
+	s.NotContains(stdout, "balanced")
+
+	// The same seed repeats the sampled picks; temperature 0 takes the top picks without a seed
+	code, stdout, stderr = s.runCLI("", "recommend", "--strategy", "hot", "--count", "3", "--seed", "9", "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stderr, "Sampling seed: 9")
+	_, again, _ := s.runCLI("", "recommend", "--strategy", "hot", "--count", "3", "--seed", "9", "--data", s.testFile)
+	s.Equal(stdout, again)
+
+	code, _, stderr = s.runCLI("", "recommend", "--temperature", "0", "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.NotContains(stderr, "Sampling seed")
+}
+
+// TestCLIStructuredOutput tests the JSON and NDJSON output of the printing commands
+func (s *CLITestSuite) TestCLIStructuredOutput() {
+	code, stdout, stderr := s.runCLI("", "analyze", "--mode", lucky.OutputModeSimple, "--output", lucky.OutputFormatJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	var report lucky.Report
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &report))
+	s.Equal(lucky.OutputModeSimple, report.Mode)
+	s.NotEmpty(report.HotNumbers)
+	s.NotEmpty(report.CosmicPick)
+
+	code, stdout, stderr = s.runCLI("", "cosmic", "--output", lucky.OutputFormatNDJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.True(strings.HasPrefix(stdout, `{"type":"summary"`))
+	s.Contains(stdout, `{"type":"cosmic_pick"`)
+	s.Contains(stdout, `{"type":"correlation"`)
+
+	code, stdout, stderr = s.runCLI("", "recommend", "--count", "3", "--output", lucky.OutputFormatJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	var sets []lucky.RecommendedSet
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &sets))
+	s.Len(sets, 3)
+
+	code, stdout, stderr = s.runCLI("", "recommend", "--count", "2", "--output", lucky.OutputFormatNDJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	s.Len(lines, 2)
+	for _, line := range lines {
+		s.True(strings.HasPrefix(line, `{"type":"recommendation"`), line)
+	}
+}
+
+// TestCLIExport tests exporting to an explicit file
+func (s *CLITestSuite) TestCLIExport() {
+	out := filepath.Join(s.T().TempDir(), "analysis.zip")
+
+	code, stdout, stderr := s.runCLI("", "export", "--format", lucky.ExportFormatCSV, "--out", out, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, out)
+	s.FileExists(out)
+
+	// CSV bundles default to a directory of tables
+	dir := filepath.Join(s.T().TempDir(), "analysis")
+	code, _, stderr = s.runCLI("", "export", "--format", lucky.ExportFormatCSV, "--out", dir, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.FileExists(filepath.Join(dir, lucky.BundleManifestFile))
+	s.FileExists(filepath.Join(dir, "drawings_enriched.csv"))
+}
+
+// TestCLIBacktest tests the walk-forward backtest in each output format
+func (s *CLITestSuite) TestCLIBacktest() {
+	code, stdout, stderr := s.runCLI("", "backtest", "--min-history", "2", "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "WALK-FORWARD BACKTEST")
+	s.Contains(stdout, "Tested drawings: 3")
+	s.Contains(stderr, "Backtesting 3 drawings")
+
+	code, stdout, stderr = s.runCLI("", "backtest", "--min-history", "2", "--last", "1", "--output", lucky.OutputFormatJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	var result lucky.BacktestResult
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &result))
+	s.Equal(1, result.Tests)
+	s.Len(result.Strategies, 6)
+
+	code, stdout, stderr = s.runCLI("", "backtest", "--min-history", "2", "--output", lucky.OutputFormatNDJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.True(strings.HasPrefix(stdout, `{"type":"backtest_summary"`))
+	s.Equal(6, strings.Count(stdout, `{"type":"backtest_strategy"`))
+
+	code, stdout, stderr = s.runCLI("", "backtest", "--min-history", "2", "--strategy", "hot", "--output", lucky.OutputFormatNDJSON, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Equal(1, strings.Count(stdout, `{"type":"backtest_strategy"`))
+
+	// Too little history is a runtime error
+	code, _, stderr = s.runCLI("", "backtest", "--data", s.testFile)
+	s.Equal(exitError, code)
+	s.Contains(stderr, lucky.ErrInsufficientHistory.Error())
+}
+
+// TestCLICheck tests checking tickets from arguments and a CSV file
+func (s *CLITestSuite) TestCLICheck() {
+	code, stdout, stderr := s.runCLI("", "check", "--data", s.testFile, "05-12-23-34-45+07")
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "TICKET 05-12-23-34-45 LB 07")
+	s.Contains(stdout, "5+LB $1,000/day for life")
+	s.Contains(stdout, "Playing every draw: cost $10.00")
+
+	tickets := filepath.Join(s.T().TempDir(), "tickets.csv")
+	s.Require().NoError(os.WriteFile(tickets, []byte("n1,n2,n3,n4,n5,lb\n1,2,3,4,6,7\n"), 0o600))
+	code, stdout, stderr = s.runCLI("", "check", "--tickets", tickets, "--output", lucky.OutputFormatJSON, "--data", s.testFile, "5,12,23,1,2,3")
+	s.Equal(exitOK, code, stderr)
+	var checks []lucky.TicketCheck
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &checks))
+	s.Require().Len(checks, 2)
+	s.Equal([]int{1, 2, 3, 4, 6}, checks[1].Ticket.Numbers)
+	s.Equal(5, checks[1].Drawings)
+
+	code, stdout, stderr = s.runCLI("", "check", "--output", lucky.OutputFormatNDJSON, "--data", s.testFile, "5-12-23-34-45+7")
+	s.Equal(exitOK, code, stderr)
+	s.True(strings.HasPrefix(stdout, `{"type":"ticket_check"`))
+
+	// Malformed tickets are usage errors; unreadable ticket files are runtime errors
+	code, _, stderr = s.runCLI("", "check", "--data", s.testFile)
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "at least one ticket")
+
+	code, _, stderr = s.runCLI("", "check", "--data", s.testFile, "5-12-23-34+7")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, lucky.ErrInvalidTicket.Error())
+
+	code, _, stderr = s.runCLI("", "check", "--tickets", "missing_tickets.csv", "--data", s.testFile)
+	s.Equal(exitError, code)
+	s.Contains(stderr, "failed to open tickets")
+}
+
+// TestFormatNumbers tests ticket number formatting
+func (s *CLITestSuite) TestFormatNumbers() {
+	s.Equal("05-12-23", formatNumbers([]int{5, 12, 23}))
+	s.Empty(formatNumbers(nil))
+}
+
+// Run the test suite
+func TestCLISuite(t *testing.T) {
+	suite.Run(t, new(CLITestSuite))
+}
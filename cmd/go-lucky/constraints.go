@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// constraintOptions holds the ticket constraint flags; set flags override the --constraints file
+type constraintOptions struct {
+	file           string
+	flags          lucky.TicketConstraints
+	noPastDrawings *bool // Nil unless --no-past-drawings was given, so the file's setting stands
+}
+
+// addConstraintFlags registers the ticket constraint and popularity flags of commands that generate recommended sets
+func addConstraintFlags(fs *flag.FlagSet, opts *cliOptions) {
+	c := &opts.constraints.flags
+	fs.StringVar(&opts.constraints.file, "constraints", "", "JSON file of ticket constraints; the flags below override it")
+	fs.Func("sum", "allowed range of the main numbers' sum, e.g. 100-150", func(value string) error {
+		minText, maxText, found := strings.Cut(value, "-")
+		minSum, minErr := strconv.Atoi(strings.TrimSpace(minText))
+		maxSum, maxErr := strconv.Atoi(strings.TrimSpace(maxText))
+		if !found || minErr != nil || maxErr != nil {
+			return fmt.Errorf("%w: want a range like 100-150", lucky.ErrInvalidConstraints)
+		}
+		c.MinSum, c.MaxSum = minSum, maxSum
+		return nil
+	})
+	fs.Func("odd-even", "comma-separated allowed odd/even splits, e.g. 3O-2E,2O-3E", func(value string) error {
+		c.OddEven = splitList(value)
+		return nil
+	})
+	fs.IntVar(&c.MaxConsecutive, "max-consecutive", 0, "longest allowed run of consecutive numbers (0 = any)")
+	fs.IntVar(&c.MinDecades, "min-decades", 0, "fewest decades (runs of ten numbers from the game's lowest) the numbers must span")
+	fs.IntVar(&c.MaxPerDecade, "max-per-decade", 0, "most numbers allowed in one decade (0 = any)")
+	fs.Func("include", "comma-separated numbers every ticket must contain", func(value string) error {
+		numbers, err := parseNumberList(value)
+		c.Include = numbers
+		return err
+	})
+	fs.Func("exclude", "comma-separated numbers no ticket may contain", func(value string) error {
+		numbers, err := parseNumberList(value)
+		c.Exclude = numbers
+		return err
+	})
+	fs.BoolFunc("no-past-drawings", "reject tickets identical to a past drawing (=false overrides the file)", func(value string) error {
+		reject, err := strconv.ParseBool(value)
+		opts.constraints.noPastDrawings = &reject
+		return err
+	})
+	fs.StringVar(&opts.popularityFile, "popularity", "", "JSON file of popularity weights for the contrarian strategy")
+}
+
+// resolveConstraints loads the --constraints file, applies the constraint flags over it and
+// validates the result for the selected game
+func resolveConstraints(opts *cliOptions) error {
+	constraints := &lucky.TicketConstraints{}
+	if opts.constraints.file != "" {
+		file, err := os.Open(opts.constraints.file) // #nosec G304 - path comes from the --constraints flag
+		if err != nil {
+			return fmt.Errorf("failed to open constraints: %w", err)
+		}
+		constraints, err = lucky.ReadTicketConstraints(file)
+		_ = file.Close()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrUsage, opts.constraints.file, err)
+		}
+	}
+
+	flags := opts.constraints.flags
+	if flags.MinSum != 0 || flags.MaxSum != 0 {
+		constraints.MinSum, constraints.MaxSum = flags.MinSum, flags.MaxSum
+	}
+	if len(flags.OddEven) > 0 {
+		constraints.OddEven = flags.OddEven
+	}
+	if flags.MaxConsecutive != 0 {
+		constraints.MaxConsecutive = flags.MaxConsecutive
+	}
+	if flags.MinDecades != 0 {
+		constraints.MinDecades = flags.MinDecades
+	}
+	if flags.MaxPerDecade != 0 {
+		constraints.MaxPerDecade = flags.MaxPerDecade
+	}
+	if len(flags.Include) > 0 {
+		constraints.Include = flags.Include
+	}
+	if len(flags.Exclude) > 0 {
+		constraints.Exclude = flags.Exclude
+	}
+	if reject := opts.constraints.noPastDrawings; reject != nil {
+		constraints.NoPastDrawings = *reject
+	}
+
+	if constraints.IsZero() {
+		return nil
+	}
+	game, err := lucky.LookupGameSpec(opts.config.Game)
+	if err != nil {
+		return err
+	}
+	if err = constraints.Validate(game); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	opts.config.Constraints = constraints
+	return nil
+}
+
+// loadPopularityModel reads the --popularity file of the contrarian strategy's weights
+func loadPopularityModel(opts *cliOptions) error {
+	if opts.popularityFile == "" {
+		return nil
+	}
+	file, err := os.Open(opts.popularityFile) // #nosec G304 - path comes from the --popularity flag
+	if err != nil {
+		return fmt.Errorf("failed to open popularity model: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	if opts.config.Popularity, err = lucky.ReadPopularityModel(file); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrUsage, opts.popularityFile, err)
+	}
+	return nil
+}
+
+// isGenerationUsageError reports whether generating sets failed because of the requested strategies,
+// constraints or popularity model
+func isGenerationUsageError(err error) bool {
+	return errors.Is(err, lucky.ErrUnknownStrategy) || errors.Is(err, lucky.ErrNothingToPlay) ||
+		errors.Is(err, lucky.ErrInvalidConstraints) || errors.Is(err, lucky.ErrUnsatisfiableConstraints) ||
+		errors.Is(err, lucky.ErrInvalidPopularityModel)
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseNumberList parses a comma-separated list of numbers
+func parseNumberList(value string) ([]int, error) {
+	items := splitList(value)
+	numbers := make([]int, len(items))
+	for i, item := range items {
+		num, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number", lucky.ErrInvalidConstraints, item)
+		}
+		numbers[i] = num
+	}
+	return numbers, nil
+}
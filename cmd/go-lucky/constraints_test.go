@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// TestCLIConstraints tests constraining recommended sets with flags and a constraints file
+func (s *CLITestSuite) TestCLIConstraints() {
+	game := s.luckyForLife()
+	code, stdout, stderr := s.runCLI("", "recommend", "--count", "5", "--temperature", "0", "--data", s.testFile,
+		"--output", lucky.OutputFormatJSON, "--sum", "90-140", "--odd-even", "3O-2E,2O-3E", "--max-consecutive", "1",
+		"--min-decades", "3", "--include", "7", "--exclude", "5,23", "--no-past-drawings")
+	s.Equal(exitOK, code, stderr)
+	var sets []lucky.RecommendedSet
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &sets))
+	s.Require().Len(sets, 5)
+	constraints := &lucky.TicketConstraints{MinSum: 90, MaxSum: 140, OddEven: []string{"3O-2E", "2O-3E"}, MaxConsecutive: 1,
+		MinDecades: 3, Include: []int{7}, Exclude: []int{5, 23}, NoPastDrawings: true}
+	for _, set := range sets {
+		s.Require().NoError(constraints.Check(game, set.Numbers, s.analyzer.Drawings()), "Set %v", set.Numbers)
+	}
+
+	// Flags override the file
+	path := filepath.Join(s.T().TempDir(), "constraints.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`{"include": [9], "exclude": [7], "max_per_decade": 1}`), 0o600))
+	code, stdout, stderr = s.runCLI("", "recommend", "--count", "1", "--temperature", "0", "--data", s.testFile,
+		"--output", lucky.OutputFormatJSON, "--constraints", path, "--include", "7", "--exclude", "8")
+	s.Equal(exitOK, code, stderr)
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &sets))
+	s.Require().Len(sets, 1)
+	s.Contains(sets[0].Numbers, 7)
+	s.NotContains(sets[0].Numbers, 8)
+	s.Require().NoError((&lucky.TicketConstraints{MaxPerDecade: 1}).Check(game, sets[0].Numbers, nil))
+
+	code, _, stderr = s.runCLI("", "backtest", "--min-history", "2", "--data", s.testFile, "--sum", "100-150")
+	s.Equal(exitOK, code, stderr)
+
+	code, _, stderr = s.runCLI("", "recommend", "--data", s.testFile, "--constraints", "missing_constraints.json")
+	s.Equal(exitError, code)
+	s.Contains(stderr, "failed to open constraints")
+
+	// A popularity file only changes the weights it names
+	popularity := filepath.Join(s.T().TempDir(), "popularity.json")
+	s.Require().NoError(os.WriteFile(popularity, []byte(`{"sequence_weight": 10}`), 0o600))
+	code, stdout, stderr = s.runCLI("", "recommend", "--count", "1", "--temperature", "0", "--data", s.testFile,
+		"--output", lucky.OutputFormatJSON, "--strategy", "contrarian", "--popularity", popularity)
+	s.Equal(exitOK, code, stderr)
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &sets))
+	s.Require().Len(sets, 1)
+	s.Equal("contrarian", sets[0].Strategy)
+
+	code, _, stderr = s.runCLI("", "recommend", "--data", s.testFile, "--popularity", "missing_popularity.json")
+	s.Equal(exitError, code)
+	s.Contains(stderr, "failed to open popularity model")
+
+	negative := filepath.Join(s.T().TempDir(), "negative.json")
+	s.Require().NoError(os.WriteFile(negative, []byte(`{"lucky_weight": -1}`), 0o600))
+	s.Require().NoError(os.WriteFile(path, []byte(`{"min_summ": 100}`), 0o600))
+	testCases := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{"malformed sum", []string{"--sum", "100"}, "want a range like 100-150"},
+		{"malformed include", []string{"--include", "7,x"}, `"x" is not a number`},
+		{"inverted sum", []string{"--sum", "150-100"}, "minimum sum 150 is above maximum sum 100"},
+		{"bad split", []string{"--odd-even", "4O-2E"}, "odd/even split"},
+		{"included and excluded", []string{"--include", "7", "--exclude", "7"}, "both included and excluded"},
+		{"unsatisfiable", []string{"--sum", "231-300"}, lucky.ErrUnsatisfiableConstraints.Error()},
+		{"unknown file field", []string{"--constraints", path}, "unknown field"},
+		{"negative popularity weight", []string{"--popularity", negative}, "lucky_weight must be a non-negative number"},
+		{"malformed no past drawings", []string{"--no-past-drawings=maybe"}, "no-past-drawings"},
+	}
+	for _, tc := range testCases {
+		code, _, stderr = s.runCLI("", append([]string{"recommend", "--data", s.testFile}, tc.args...)...)
+		s.Equal(exitUsage, code, "Test case: %s", tc.name)
+		s.Contains(stderr, tc.stderr, "Test case: %s", tc.name)
+	}
+}
+
+// TestCLIConstraintFileOverride tests that an explicit --no-past-drawings=false overrides the file
+func (s *CLITestSuite) TestCLIConstraintFileOverride() {
+	path := filepath.Join(s.T().TempDir(), "constraints.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`{"no_past_drawings": true}`), 0o600))
+	resolve := func(args ...string) *lucky.TicketConstraints {
+		opts := &cliOptions{config: &lucky.AnalysisConfig{Game: lucky.GameLuckyForLife}}
+		fs := flag.NewFlagSet("recommend", flag.ContinueOnError)
+		addConstraintFlags(fs, opts)
+		s.Require().NoError(fs.Parse(append([]string{"--constraints", path}, args...)))
+		s.Require().NoError(resolveConstraints(opts))
+		return opts.config.Constraints
+	}
+
+	s.Require().NotNil(resolve())
+	s.True(resolve().NoPastDrawings)
+	s.True(resolve("--no-past-drawings").NoPastDrawings)
+	s.Nil(resolve("--no-past-drawings=false")) // Nothing is left to constrain
+}
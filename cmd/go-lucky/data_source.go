@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// ErrDataNotFound indicates no lottery history file could be located
+var ErrDataNotFound = errors.New("lottery history data not found")
+
+const (
+	// envDataPath overrides the history file location when --data is not given
+	envDataPath = "GO_LUCKY_DATA"
+
+	// stdinDataPath tells the CLI to read the history CSV from standard input
+	stdinDataPath = "-"
+
+	// appDataDir is the per-user data directory name under the XDG data home
+	appDataDir = "go-lucky"
+
+	// envLedgerPath overrides the ledger file location when --ledger is not given
+	envLedgerPath = "GO_LUCKY_LEDGER"
+
+	// ledgerFileName is the ledger file kept in the per-user data directory
+	ledgerFileName = "ledger.json"
+)
+
+// resolveDataPath determines which history file to read.
+//
+// Search order: the --data flag, then the GO_LUCKY_DATA environment variable,
+// then $XDG_DATA_HOME/go-lucky (or ~/.local/share/go-lucky), then the data
+// directory bundled with the repository. Explicit locations (flag or env) are
+// returned as-is so a typo surfaces as an open error rather than a silent fallback.
+func resolveDataPath(flagPath string, game *lucky.GameSpec) (string, error) {
+	if flagPath != "" {
+		return flagPath, nil
+	}
+	if envPath := strings.TrimSpace(os.Getenv(envDataPath)); envPath != "" {
+		return envPath, nil
+	}
+
+	candidates := dataPathCandidates(game)
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: searched %s (use --data or %s)", ErrDataNotFound, strings.Join(candidates, ", "), envDataPath)
+}
+
+// dataPathCandidates lists the implicit locations checked for a game's history file
+func dataPathCandidates(game *lucky.GameSpec) []string {
+	candidates := make([]string, 0, 3)
+
+	if dataHome := xdgDataHome(); dataHome != "" {
+		candidates = append(candidates, filepath.Join(dataHome, appDataDir, game.DataFile))
+	}
+
+	// Bundled data, relative to the repository root and to cmd/go-lucky
+	candidates = append(candidates,
+		filepath.Join("data", game.DataFile),
+		filepath.Join("..", "..", "data", game.DataFile),
+	)
+
+	return candidates
+}
+
+// xdgDataHome returns $XDG_DATA_HOME, falling back to ~/.local/share per the XDG base directory spec
+func xdgDataHome() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return dataHome
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share")
+}
+
+// defaultLedgerPath returns $GO_LUCKY_LEDGER, else the ledger file in the per-user data
+// directory, else a ledger file in the working directory
+func defaultLedgerPath() string {
+	if envPath := strings.TrimSpace(os.Getenv(envLedgerPath)); envPath != "" {
+		return envPath
+	}
+	if dataHome := xdgDataHome(); dataHome != "" {
+		return filepath.Join(dataHome, appDataDir, ledgerFileName)
+	}
+	return ledgerFileName
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// TestResolveDataPathExplicit tests that the --data flag and GO_LUCKY_DATA are used as given
+func (s *CLITestSuite) TestResolveDataPathExplicit() {
+	game := s.luckyForLife()
+	s.T().Setenv(envDataPath, "/env/history.csv")
+
+	// Flag wins over the environment
+	path, err := resolveDataPath("/flag/history.csv", game)
+	s.Require().NoError(err)
+	s.Equal("/flag/history.csv", path)
+
+	// Environment is used when the flag is empty, even if the file does not exist
+	path, err = resolveDataPath("", game)
+	s.Require().NoError(err)
+	s.Equal("/env/history.csv", path)
+}
+
+// TestResolveDataPathXDG tests discovery of history files in the XDG data directory
+func (s *CLITestSuite) TestResolveDataPathXDG() {
+	game := s.luckyForLife()
+	dataHome := s.T().TempDir()
+	s.T().Setenv(envDataPath, "")
+	s.T().Setenv("XDG_DATA_HOME", dataHome)
+
+	expected := filepath.Join(dataHome, appDataDir, game.DataFile)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(expected), 0o750))
+	s.Require().NoError(os.WriteFile(expected, []byte("Date\n"), 0o600))
+
+	path, err := resolveDataPath("", game)
+	s.Require().NoError(err)
+	s.Equal(expected, path)
+}
+
+// TestResolveDataPathNotFound tests the error listing every searched location
+func (s *CLITestSuite) TestResolveDataPathNotFound() {
+	game := &lucky.GameSpec{DataFile: "no-such-history.csv"}
+	dataHome := s.T().TempDir()
+	s.T().Setenv(envDataPath, "")
+	s.T().Setenv("XDG_DATA_HOME", dataHome)
+
+	_, err := resolveDataPath("", game)
+	s.Require().ErrorIs(err, ErrDataNotFound)
+	s.Contains(err.Error(), filepath.Join(dataHome, appDataDir, game.DataFile))
+	s.Contains(err.Error(), filepath.Join("data", game.DataFile))
+	s.Contains(err.Error(), envDataPath)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+const (
+	// defaultBankrollTicketsPerWeek is how many tickets the ev subcommand buys each simulated week
+	defaultBankrollTicketsPerWeek = 1
+
+	// defaultBankrollYears is how long the ev subcommand simulates play
+	defaultBankrollYears = 10
+)
+
+// runEV prints the exact odds and expected return of a ticket and simulates a bankroll of weekly play
+func runEV(_ context.Context, opts *cliOptions, _ []string) error {
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	game, err := lucky.LookupGameSpec(opts.config.Game)
+	if err != nil {
+		return err
+	}
+	prizes, err := lucky.PrizeTableFor(game.Key)
+	if errors.Is(err, lucky.ErrNoPrizeTable) {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	} else if err != nil {
+		return err
+	}
+
+	if opts.bankroll.Seed == 0 {
+		opts.bankroll.Seed = rand.Int64N(math.MaxInt64) + 1 //nolint:gosec // a seed for the simulation, not a secret
+	}
+	_, _ = fmt.Fprintf(opts.stderr, "🎲 Simulation seed: %d (repeat this bankroll with --seed %d)\n", opts.bankroll.Seed, opts.bankroll.Seed)
+	bankroll, err := prizes.SimulateBankroll(game, opts.bankroll)
+	if errors.Is(err, lucky.ErrInvalidBankroll) {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	} else if err != nil {
+		return err
+	}
+	report := &lucky.ValueReport{ExpectedValue: prizes.ExpectedValueReport(game), Bankroll: bankroll}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, report)
+	case lucky.OutputFormatNDJSON:
+		return lucky.WriteRecords(opts.stdout, report.Records())
+	}
+	return lucky.WriteValueReport(opts.stdout, *game, report)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// TestCLIEV tests the expected value report and its repeatable bankroll simulation
+func (s *CLITestSuite) TestCLIEV() {
+	code, stdout, stderr := s.runCLI("", "ev", "--years", "2", "--tickets-per-week", "3")
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stderr, "Simulation seed:")
+	s.Contains(stdout, "EXPECTED VALUE")
+	s.Contains(stdout, "Expected return per $2.00 ticket: $1.1532")
+	s.Contains(stdout, "3 ticket(s) a week for 2 year(s), 312 tickets")
+
+	code, stdout, stderr = s.runCLI("", "ev", "--years", "1", "--seed", "9", "--output", lucky.OutputFormatJSON)
+	s.Equal(exitOK, code, stderr)
+	var report lucky.ValueReport
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &report))
+	s.Len(report.ExpectedValue.Tiers, 10)
+	s.Len(report.Bankroll.Curve, 52)
+	s.Equal(int64(9), report.Bankroll.Config.Seed)
+	_, repeat, _ := s.runCLI("", "ev", "--years", "1", "--seed", "9", "--output", lucky.OutputFormatJSON)
+	s.Equal(stdout, repeat)
+
+	code, stdout, stderr = s.runCLI("", "ev", "--years", "1", "--output", lucky.OutputFormatNDJSON)
+	s.Equal(exitOK, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	s.Len(lines, 54)
+	s.True(strings.HasPrefix(lines[0], `{"type":"expected_value"`))
+	s.True(strings.HasPrefix(lines[1], `{"type":"bankroll_summary"`))
+	s.True(strings.HasPrefix(lines[2], `{"type":"bankroll_point"`))
+
+	// Recommendations show the same honest value for every set
+	code, stdout, stderr = s.runCLI("", "recommend", "--count", "2", "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Equal(2, strings.Count(stdout, "Expected return $1.15 per $2.00 ticket (ROI -42.3%), any prize 1 in 7.77"))
+	s.NotContains(stdout, "Confidence")
+
+	testCases := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{"no tickets", []string{"--tickets-per-week", "0"}, "tickets per week must be between 1 and"},
+		{"too many years", []string{"--years", "101"}, "years must be between 1 and 100"},
+		{"unknown game prizes", []string{"--game", lucky.GamePowerball}, lucky.ErrNoPrizeTable.Error()},
+		{"bad output", []string{"--output", "xml"}, "unknown output format"},
+	}
+	for _, tc := range testCases {
+		code, _, stderr = s.runCLI("", append([]string{"ev"}, tc.args...)...)
+		s.Equal(exitUsage, code, "Test case: %s", tc.name)
+		s.Contains(stderr, tc.stderr, "Test case: %s", tc.name)
+	}
+}
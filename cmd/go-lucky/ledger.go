@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// Ledger actions
+const (
+	ledgerActionAdd       = "add"
+	ledgerActionList      = "list"
+	ledgerActionReconcile = "reconcile"
+)
+
+// ledgerOptions holds the flags of the ledger subcommand
+type ledgerOptions struct {
+	path      string
+	owner     string
+	purchased string
+	firstDraw string
+	lastDraw  string
+	cost      float64
+}
+
+// runLedger dispatches the ledger actions
+func runLedger(ctx context.Context, opts *cliOptions, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: give an action: %s|%s|%s", ErrUsage, ledgerActionAdd, ledgerActionList, ledgerActionReconcile)
+	}
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	if opts.ledger.path == "" {
+		opts.ledger.path = defaultLedgerPath()
+	}
+
+	action, args := args[0], args[1:]
+	switch action {
+	case ledgerActionAdd:
+		return runLedgerAdd(opts, args)
+	case ledgerActionList, ledgerActionReconcile:
+		if len(args) > 0 {
+			return fmt.Errorf("%w: unexpected argument %q", ErrUsage, args[0])
+		}
+	default:
+		return fmt.Errorf("%w: unknown ledger action %q", ErrUsage, action)
+	}
+
+	ledger, err := lucky.LoadLedger(opts.ledger.path)
+	if err != nil {
+		return err
+	}
+	if action == ledgerActionReconcile {
+		analyzer, loadErr := loadAnalyzer(ctx, opts)
+		if loadErr != nil {
+			return loadErr
+		}
+		added := ledger.Reconcile(analyzer)
+		if err = ledger.Save(opts.ledger.path); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(opts.stderr, "Reconciled %d new drawing results against %d drawings\n", added, len(analyzer.Drawings()))
+	}
+	return writeLedger(opts, ledger)
+}
+
+// runLedgerAdd records the given tickets for one owner and saves the ledger
+func runLedgerAdd(opts *cliOptions, args []string) error {
+	game, err := lucky.LookupGameSpec(opts.config.Game)
+	if err != nil {
+		return err
+	}
+
+	tickets := make([]lucky.Ticket, 0, len(args))
+	for _, arg := range args {
+		ticket, parseErr := lucky.ParseTicket(game, arg)
+		if parseErr != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, parseErr)
+		}
+		tickets = append(tickets, ticket)
+	}
+	if opts.tickets != "" {
+		fromFile, readErr := readTicketFile(game, opts.tickets)
+		if readErr != nil {
+			return readErr
+		}
+		tickets = append(tickets, fromFile...)
+	}
+	if len(tickets) == 0 {
+		return fmt.Errorf("%w: give at least one ticket (e.g. 05-12-23-34-45+07) or --tickets", ErrUsage)
+	}
+
+	// Tickets are usually bought for the next drawing, so the dates default to the purchase day
+	purchased := time.Now()
+	if opts.ledger.purchased != "" {
+		if purchased, err = parseDateFlag("purchased", opts.ledger.purchased); err != nil {
+			return err
+		}
+	}
+	first := purchased
+	if opts.ledger.firstDraw != "" {
+		if first, err = parseDateFlag("first", opts.ledger.firstDraw); err != nil {
+			return err
+		}
+	}
+	last := first
+	if opts.ledger.lastDraw != "" {
+		if last, err = parseDateFlag("last", opts.ledger.lastDraw); err != nil {
+			return err
+		}
+	}
+
+	ledger, err := lucky.LoadLedger(opts.ledger.path)
+	if err != nil {
+		return err
+	}
+	added := make([]lucky.LedgerEntry, 0, len(tickets))
+	for _, ticket := range tickets {
+		entry, addErr := ledger.Add(game, lucky.LedgerEntry{
+			Owner:        opts.ledger.owner,
+			Ticket:       ticket,
+			PurchaseDate: purchased,
+			FirstDraw:    first,
+			LastDraw:     last,
+			Cost:         opts.ledger.cost,
+		})
+		if addErr != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, addErr)
+		}
+		added = append(added, entry)
+	}
+	if err = ledger.Save(opts.ledger.path); err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, added)
+	case lucky.OutputFormatNDJSON:
+		records := make([]lucky.Record, len(added))
+		for i, entry := range added {
+			records[i] = lucky.Record{Type: lucky.RecordTypeLedgerEntry, Data: entry}
+		}
+		return lucky.WriteRecords(opts.stdout, records)
+	}
+	for _, entry := range added {
+		_, _ = fmt.Fprintf(opts.stdout, "Added ticket #%d for %s: %s", entry.ID, entry.Owner, formatNumbers(entry.Ticket.Numbers))
+		if game.HasBonus() {
+			_, _ = fmt.Fprintf(opts.stdout, "  %s: %d", game.BonusName, entry.Ticket.LuckyBall)
+		}
+		_, _ = fmt.Fprintf(opts.stdout, "  (%s to %s, $%.2f)\n",
+			entry.FirstDraw.Format(time.DateOnly), entry.LastDraw.Format(time.DateOnly), entry.Cost)
+	}
+	_, _ = fmt.Fprintf(opts.stdout, "Ledger saved to: %s\n", opts.ledger.path)
+	return nil
+}
+
+// writeLedger prints the ledger entries and owner positions in the selected output format
+func writeLedger(opts *cliOptions, ledger *lucky.Ledger) error {
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, struct {
+			Entries   []lucky.LedgerEntry   `json:"entries"`
+			Positions []lucky.OwnerPosition `json:"positions"`
+		}{ledger.Entries, ledger.Positions()})
+	case lucky.OutputFormatNDJSON:
+		return lucky.WriteRecords(opts.stdout, ledger.Records())
+	}
+	return lucky.WriteLedger(opts.stdout, ledger)
+}
+
+// parseDateFlag parses a YYYY-MM-DD or MM/DD/YYYY date flag value
+func parseDateFlag(name, value string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "01/02/2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: --%s must be a date like 2024-01-15, got %q", ErrUsage, name, value)
+}
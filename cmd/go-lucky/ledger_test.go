@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// TestCLILedger tests adding tickets to the ledger, reconciling them and listing positions
+func (s *CLITestSuite) TestCLILedger() {
+	path := filepath.Join(s.T().TempDir(), "ledger.json")
+
+	// Flags may follow the action and the tickets
+	code, stdout, stderr := s.runCLI("", "ledger", "add", "05-12-23-34-45+07", "--ledger", path, "--owner", "alice",
+		"--purchased", "2024-01-11", "--first", "2024-01-12", "--last", "2024-01-15")
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "Added ticket #1 for alice")
+	s.Contains(stdout, "$8.00")
+
+	code, _, stderr = s.runCLI("", "ledger", "--ledger", path, "--owner", "bob", "--first", "01/03/2024", "add", "1,2,4,6,8,3")
+	s.Equal(exitOK, code, stderr)
+
+	code, stdout, stderr = s.runCLI("", "ledger", "reconcile", "--ledger", path, "--data", s.testFile)
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stderr, "Reconciled 3 new drawing results")
+	s.Contains(stdout, "5+LB on 01/15/2024")
+	s.Contains(stdout, "Positions:")
+
+	code, stdout, stderr = s.runCLI("", "ledger", "list", "--ledger", path, "--output", lucky.OutputFormatJSON)
+	s.Equal(exitOK, code, stderr)
+	var listed struct {
+		Entries   []lucky.LedgerEntry   `json:"entries"`
+		Positions []lucky.OwnerPosition `json:"positions"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &listed))
+	s.Require().Len(listed.Entries, 2)
+	s.Require().Len(listed.Positions, 2)
+	s.Equal("bob", listed.Positions[1].Owner)
+	s.InDelta(4.0, listed.Positions[1].Net, 1e-9) // 1+LB pays $6 on a $2 ticket
+
+	code, stdout, stderr = s.runCLI("", "ledger", "list", "--ledger", path, "--output", lucky.OutputFormatNDJSON)
+	s.Equal(exitOK, code, stderr)
+	s.True(strings.HasPrefix(stdout, `{"type":"ledger_entry"`))
+	s.Contains(stdout, `{"type":"ledger_position"`)
+
+	// Everything after -- is a ticket, even text that looks like a flag
+	code, _, stderr = s.runCLI("", "ledger", "add", "--ledger", path, "--owner", "carol", "--", "--owner")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, lucky.ErrInvalidTicket.Error())
+
+	testCases := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{"no action", []string{"ledger", "--ledger", path}, "give an action"},
+		{"unknown action", []string{"ledger", "remove", "--ledger", path}, "unknown ledger action"},
+		{"list argument", []string{"ledger", "list", "extra", "--ledger", path}, "unexpected argument"},
+		{"no ticket", []string{"ledger", "add", "--ledger", path, "--owner", "carol"}, "at least one ticket"},
+		{"no owner", []string{"ledger", "add", "5-12-23-34-45+7", "--ledger", path}, "owner is required"},
+		{"bad date", []string{"ledger", "add", "5-12-23-34-45+7", "--ledger", path, "--owner", "carol", "--first", "soon"}, "--first must be a date"},
+	}
+	for _, tc := range testCases {
+		code, _, stderr = s.runCLI("", tc.args...)
+		s.Equal(exitUsage, code, "Test case: %s", tc.name)
+		s.Contains(stderr, tc.stderr, "Test case: %s", tc.name)
+	}
+}
+
+// TestDefaultLedgerPath tests the ledger location environment override and XDG default
+func (s *CLITestSuite) TestDefaultLedgerPath() {
+	s.T().Setenv(envLedgerPath, "custom.json")
+	s.Equal("custom.json", defaultLedgerPath())
+
+	s.T().Setenv(envLedgerPath, "")
+	s.T().Setenv("XDG_DATA_HOME", "/tmp/xdg")
+	s.Equal(filepath.Join("/tmp/xdg", appDataDir, ledgerFileName), defaultLedgerPath())
+}
@@ -15,97 +15,13 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
-	"strconv"
-	"time"
+	"os/signal"
 )
 
 func main() {
-	ctx := context.Background()
-
-	// Default configuration
-	config := &AnalysisConfig{
-		RecentWindow:     50,
-		MinGapMultiplier: 1.5,
-		ConfidenceLevel:  0.95,
-		OutputMode:       outputModeDetailed,
-		ExportFormat:     exportFormatConsole,
-	}
-
-	// Parse command line arguments
-	if len(os.Args) > 1 {
-		for i := 1; i < len(os.Args); i++ {
-			switch os.Args[i] {
-			case "--simple":
-				config.OutputMode = "simple"
-			case "--statistical":
-				config.OutputMode = "statistical"
-			case "--cosmic":
-				config.OutputMode = "cosmic"
-			case "--export-json":
-				config.ExportFormat = "json"
-			case "--export-csv":
-				config.ExportFormat = "csv"
-			case "--recent":
-				if i+1 < len(os.Args) {
-					if val, err := strconv.Atoi(os.Args[i+1]); err == nil {
-						config.RecentWindow = val
-						i++
-					}
-				}
-			case "--help":
-				printHelp()
-				return
-			}
-		}
-	}
-
-	// Create analyzer
-	analyzer, err := NewAnalyzer(ctx, "../../data/lucky-numbers-history.csv", config)
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Run analysis
-	if err = analyzer.RunAnalysis(ctx); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error running analysis: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Export if requested
-	if config.ExportFormat != exportFormatConsole {
-		filename := fmt.Sprintf("lottery_analysis_%s.%s",
-			time.Now().Format("20060102_150405"),
-			config.ExportFormat)
-		if err = analyzer.ExportAnalysis(ctx, filename); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error exporting analysis: %v\n", err)
-			os.Exit(1)
-		}
-		_, _ = fmt.Fprintf(os.Stdout, "\nAnalysis exported to: %s\n", filename)
-	}
-}
-
-// printHelp displays usage information
-func printHelp() {
-	_, _ = fmt.Fprintln(os.Stdout, "NC Lucky for Life Lottery Analyzer")
-	_, _ = fmt.Fprintln(os.Stdout, "==================================")
-	_, _ = fmt.Fprintln(os.Stdout)
-	_, _ = fmt.Fprintln(os.Stdout, "Usage: go run lottery_analyzer.go [options]")
-	_, _ = fmt.Fprintln(os.Stdout)
-	_, _ = fmt.Fprintln(os.Stdout, "Options:")
-	_, _ = fmt.Fprintln(os.Stdout, "  --simple           Show simplified analysis")
-	_, _ = fmt.Fprintln(os.Stdout, "  --statistical      Show detailed statistical analysis")
-	_, _ = fmt.Fprintln(os.Stdout, "  --cosmic           Show cosmic correlation analysis")
-	_, _ = fmt.Fprintln(os.Stdout, "  --export-json      Export results to JSON file")
-	_, _ = fmt.Fprintln(os.Stdout, "  --export-csv       Export results to CSV file")
-	_, _ = fmt.Fprintln(os.Stdout, "  --recent <n>       Set recent window size (default: 50)")
-	_, _ = fmt.Fprintln(os.Stdout, "  --help             Show this help message")
-	_, _ = fmt.Fprintln(os.Stdout)
-	_, _ = fmt.Fprintln(os.Stdout, "Examples:")
-	_, _ = fmt.Fprintln(os.Stdout, "  go run lottery_analyzer.go")
-	_, _ = fmt.Fprintln(os.Stdout, "  go run lottery_analyzer.go --simple")
-	_, _ = fmt.Fprintln(os.Stdout, "  go run lottery_analyzer.go --statistical --export-json")
-	_, _ = fmt.Fprintln(os.Stdout, "  go run lottery_analyzer.go --recent 100")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
 }
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// ErrInvalidQuery indicates a malformed API query parameter
+var ErrInvalidQuery = errors.New("invalid query parameter")
+
+const (
+	// API server timeouts
+	serveReadHeaderTimeout = 5 * time.Second
+	serveShutdownTimeout   = 5 * time.Second
+
+	// maxAPISets caps how many recommendation sets one request can ask for
+	maxAPISets = 20
+)
+
+// apiServer exposes a loaded analyzer over HTTP
+type apiServer struct {
+	mu       sync.Mutex // Serializes access to the analyzer
+	analyzer *lucky.Analyzer
+}
+
+// apiSummary is the response of the summary endpoint
+type apiSummary struct {
+	Game            lucky.GameSpec       `json:"game"`
+	TotalDrawings   int                  `json:"total_drawings"`
+	FirstDrawing    time.Time            `json:"first_drawing"`
+	LastDrawing     time.Time            `json:"last_drawing"`
+	ChiSquare       float64              `json:"chi_square"`
+	MainChiSquare   lucky.ChiSquareTest  `json:"main_chi_square"`
+	BonusChiSquare  *lucky.ChiSquareTest `json:"bonus_chi_square,omitempty"`
+	RandomnessScore float64              `json:"randomness_score"`
+}
+
+// apiCosmic is the response of the cosmic endpoint
+type apiCosmic struct {
+	Numbers   []int `json:"numbers"`
+	LuckyBall int   `json:"lucky_ball,omitempty"`
+}
+
+// apiError is the body returned for failed requests
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// newServeMux routes the JSON API endpoints to an analyzer
+func newServeMux(analyzer *lucky.Analyzer) *http.ServeMux {
+	srv := &apiServer{analyzer: analyzer}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/summary", srv.handleSummary)
+	mux.HandleFunc("GET /api/hot", srv.handleHot)
+	mux.HandleFunc("GET /api/overdue", srv.handleOverdue)
+	mux.HandleFunc("GET /api/recommendations", srv.handleRecommendations)
+	mux.HandleFunc("GET /api/cosmic", srv.handleCosmic)
+	return mux
+}
+
+// runServe loads the analyzer and serves the JSON API until the context is canceled
+func runServe(ctx context.Context, opts *cliOptions, _ []string) error {
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           newServeMux(analyzer),
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx) //nolint:contextcheck // parent context is already canceled
+	}()
+
+	_, _ = fmt.Fprintf(opts.stderr, "Serving %s analysis on http://%s/api/\n", analyzer.Game().Name, listener.Addr())
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleSummary returns the dataset overview and randomness statistics
+func (s *apiServer) handleSummary(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	drawings := s.analyzer.Drawings()
+	summary := apiSummary{
+		Game:            s.analyzer.Game(),
+		TotalDrawings:   len(drawings),
+		ChiSquare:       s.analyzer.ChiSquareValue(),
+		MainChiSquare:   s.analyzer.MainChiSquare(),
+		RandomnessScore: s.analyzer.RandomnessScore(),
+	}
+	if summary.Game.HasBonus() {
+		bonus := s.analyzer.BonusChiSquare()
+		summary.BonusChiSquare = &bonus
+	}
+	if len(drawings) > 0 {
+		summary.FirstDrawing = drawings[len(drawings)-1].Date
+		summary.LastDrawing = drawings[0].Date
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleHot returns the most frequent recent numbers
+func (s *apiServer) handleHot(w http.ResponseWriter, r *http.Request) {
+	count, err := queryCount(r, 10, s.analyzer.Game().MainPoolSize)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.analyzer.GetTopNumbers(count, true))
+}
+
+// handleOverdue returns the numbers furthest past their average gap
+func (s *apiServer) handleOverdue(w http.ResponseWriter, r *http.Request) {
+	count, err := queryCount(r, 10, s.analyzer.Game().MainPoolSize)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.analyzer.GetOverdueNumbers(count))
+}
+
+// handleRecommendations returns generated number sets
+func (s *apiServer) handleRecommendations(w http.ResponseWriter, r *http.Request) {
+	count, err := queryCount(r, 5, maxAPISets)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, apiError{Error: err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recommendations, err := s.analyzer.GenerateRecommendations(r.Context(), count)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, recommendations)
+}
+
+// handleCosmic returns the cosmic pick for the current conditions
+func (s *apiServer) handleCosmic(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pick := s.analyzer.CorrelationEngine().PredictBasedOnCosmicConditions()
+	writeJSON(w, http.StatusOK, apiCosmic{Numbers: pick.Numbers, LuckyBall: pick.LuckyBall})
+}
+
+// queryCount reads the optional "count" query parameter within [1, limit]
+func queryCount(r *http.Request, fallback, limit int) (int, error) {
+	raw := r.URL.Query().Get("count")
+	if raw == "" {
+		return fallback, nil
+	}
+	count, err := strconv.Atoi(raw)
+	if err != nil || count < 1 || count > limit {
+		return 0, fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidQuery, limit)
+	}
+	return count, nil
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// serveRequest performs a request against the API mux and returns the recorder
+func (s *CLITestSuite) serveRequest(method, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	newServeMux(s.analyzer).ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	return recorder
+}
+
+// TestServeEndpoints tests the JSON API responses
+func (s *CLITestSuite) TestServeEndpoints() {
+	resp := s.serveRequest(http.MethodGet, "/api/summary")
+	s.Equal(http.StatusOK, resp.Code)
+	s.Equal("application/json", resp.Header().Get("Content-Type"))
+	var summary apiSummary
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &summary))
+	s.Equal(5, summary.TotalDrawings)
+	s.Equal(lucky.GameLuckyForLife, summary.Game.Key)
+	s.Equal(47, summary.MainChiSquare.DegreesOfFreedom)
+	s.Require().NotNil(summary.BonusChiSquare)
+	s.Equal(17, summary.BonusChiSquare.DegreesOfFreedom)
+
+	resp = s.serveRequest(http.MethodGet, "/api/hot?count=3")
+	s.Equal(http.StatusOK, resp.Code)
+	var hot []lucky.NumberInfo
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &hot))
+	s.Len(hot, 3)
+
+	resp = s.serveRequest(http.MethodGet, "/api/overdue")
+	s.Equal(http.StatusOK, resp.Code)
+
+	resp = s.serveRequest(http.MethodGet, "/api/recommendations?count=2")
+	s.Equal(http.StatusOK, resp.Code)
+	var recommendations []lucky.RecommendedSet
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &recommendations))
+	s.Len(recommendations, 2)
+
+	resp = s.serveRequest(http.MethodGet, "/api/cosmic")
+	s.Equal(http.StatusOK, resp.Code)
+	var cosmic apiCosmic
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &cosmic))
+	s.Len(cosmic.Numbers, 5)
+}
+
+// TestServeErrors tests rejected API requests
+func (s *CLITestSuite) TestServeErrors() {
+	for _, target := range []string{"/api/hot?count=abc", "/api/hot?count=0", "/api/recommendations?count=500"} {
+		resp := s.serveRequest(http.MethodGet, target)
+		s.Equal(http.StatusBadRequest, resp.Code, target)
+		s.Contains(resp.Body.String(), "count must be between")
+	}
+
+	resp := s.serveRequest(http.MethodPost, "/api/summary")
+	s.Equal(http.StatusMethodNotAllowed, resp.Code)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// defaultWheelPoolSize is how many numbers the wheel subcommand takes from --pool
+const defaultWheelPoolSize = 12
+
+// wheelOptions holds the flags of the wheel subcommand
+type wheelOptions struct {
+	kind      string
+	poolFrom  string
+	size      int
+	keys      string
+	guarantee string
+	luckyBall int
+}
+
+// runWheel builds a wheel over the given numbers, or over the analysis' best numbers
+func runWheel(ctx context.Context, opts *cliOptions, args []string) error {
+	if _, err := lucky.NewRenderer("", opts.output); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	config := lucky.WheelConfig{Type: opts.wheel.kind, LuckyBall: opts.wheel.luckyBall}
+	var err error
+	if config.Guarantee, config.Condition, err = parseGuarantee(opts.wheel.guarantee); err != nil {
+		return err
+	}
+	if config.Keys, err = parseNumberList(opts.wheel.keys); err != nil {
+		return fmt.Errorf("%w: --keys: %w", ErrUsage, err)
+	}
+	if config.Pool, err = parseNumberList(strings.Join(args, ",")); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	analyzer, err := loadAnalyzer(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(config.Pool) == 0 {
+		if opts.wheel.size <= 0 {
+			return fmt.Errorf("%w: --size must be positive, got %d", ErrUsage, opts.wheel.size)
+		}
+		best, poolErr := analyzer.WheelPool(opts.wheel.poolFrom, opts.wheel.size+len(config.Keys))
+		if poolErr != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, poolErr)
+		}
+		for _, num := range best {
+			if len(config.Pool) < opts.wheel.size && !slices.Contains(config.Keys, num) {
+				config.Pool = append(config.Pool, num)
+			}
+		}
+	}
+	wheel, err := analyzer.Wheel(config)
+	if errors.Is(err, lucky.ErrInvalidWheel) || errors.Is(err, lucky.ErrUnknownStrategy) {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	} else if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case lucky.OutputFormatJSON:
+		return writeJSONDocument(opts.stdout, wheel)
+	case lucky.OutputFormatNDJSON:
+		return lucky.WriteRecords(opts.stdout, wheel.Records())
+	}
+	return lucky.WriteWheel(opts.stdout, analyzer.Game(), wheel)
+}
+
+// parseGuarantee parses a "k-if-m" guarantee; an empty value leaves both zero for the wheel's default
+func parseGuarantee(value string) (int, int, error) {
+	if value == "" {
+		return 0, 0, nil
+	}
+	guaranteeText, conditionText, found := strings.Cut(strings.ToLower(value), "-if-")
+	guarantee, guaranteeErr := strconv.Atoi(strings.TrimSpace(guaranteeText))
+	condition, conditionErr := strconv.Atoi(strings.TrimSpace(conditionText))
+	if !found || guaranteeErr != nil || conditionErr != nil {
+		return 0, 0, fmt.Errorf("%w: --guarantee must look like 3-if-4, got %q", ErrUsage, value)
+	}
+	return guarantee, condition, nil
+}
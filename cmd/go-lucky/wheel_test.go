@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mrz1836/go-lucky/lucky"
+)
+
+// TestCLIWheel tests building wheels from given numbers and from the analysis
+func (s *CLITestSuite) TestCLIWheel() {
+	code, stdout, stderr := s.runCLI("", "wheel", "1", "5", "9", "12", "17", "23", "28", "31", "--data", s.testFile, "--guarantee", "3-if-4")
+	s.Equal(exitOK, code, stderr)
+	s.Contains(stdout, "ABBREVIATED WHEEL")
+	s.Contains(stdout, "Pool (8 numbers): 01-05-09-12-17-23-28-31")
+	s.Contains(stdout, "✓ Proven: checked all 70 combinations")
+
+	code, stdout, stderr = s.runCLI("", "wheel", "--type", lucky.WheelKey, "--keys", "23", "--size", "6", "--lucky-ball", "3",
+		"--data", s.testFile, "--output", lucky.OutputFormatJSON)
+	s.Equal(exitOK, code, stderr)
+	var wheel lucky.Wheel
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &wheel))
+	s.Equal([]int{23}, wheel.Keys)
+	s.Len(wheel.Pool, 6)
+	s.NotContains(wheel.Pool, 23)
+	s.True(wheel.Proven)
+	for _, ticket := range wheel.Tickets {
+		s.Contains(ticket.Numbers, 23)
+		s.Equal(3, ticket.LuckyBall)
+	}
+
+	code, stdout, stderr = s.runCLI("", "wheel", "--type", lucky.WheelFull, "--size", "6", "--data", s.testFile, "--output", lucky.OutputFormatNDJSON)
+	s.Equal(exitOK, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	s.Len(lines, 7)
+	s.True(strings.HasPrefix(lines[0], `{"type":"wheel_summary"`))
+	s.True(strings.HasPrefix(lines[1], `{"type":"wheel_ticket"`))
+
+	testCases := []struct {
+		name   string
+		args   []string
+		stderr string
+	}{
+		{"bad guarantee", []string{"--guarantee", "3of4"}, "--guarantee must look like 3-if-4"},
+		{"impossible guarantee", []string{"--guarantee", "4-if-3"}, "guarantee 4-if-3"},
+		{"unknown type", []string{"--type", "partial"}, "unknown type"},
+		{"unknown pool", []string{"--pool", "lucky"}, "unknown pool source"},
+		{"zero size", []string{"--size", "0"}, "--size must be positive"},
+		{"bad number", []string{"1", "2", "x"}, `"x" is not a number`},
+		{"bad lucky ball", []string{"--lucky-ball", "19"}, "Lucky Ball 19"},
+		{"unknown strategy", []string{"--strategy", "mine"}, "unknown strategy"},
+	}
+	for _, tc := range testCases {
+		code, _, stderr = s.runCLI("", append([]string{"wheel", "--data", s.testFile}, tc.args...)...)
+		s.Equal(exitUsage, code, "Test case: %s", tc.name)
+		s.Contains(stderr, tc.stderr, "Test case: %s", tc.name)
+	}
+}
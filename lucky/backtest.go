@@ -0,0 +1,371 @@
+package lucky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// ErrInsufficientHistory indicates too few drawings to backtest with the requested history
+var ErrInsufficientHistory = errors.New("insufficient drawing history")
+
+const (
+	// DefaultBacktestMinHistory is the number of earlier drawings required before a drawing is tested
+	DefaultBacktestMinHistory = 100
+
+	// backtestMinExpected is the smallest expected bin count the baseline chi-square test allows
+	backtestMinExpected = 5
+)
+
+// BacktestConfig controls a walk-forward backtest
+type BacktestConfig struct {
+	MinHistory int // Earlier drawings required before a drawing is tested; defaults to DefaultBacktestMinHistory
+	MaxTests   int // Test only the most recent MaxTests eligible drawings; 0 tests them all
+}
+
+// BacktestResult is the outcome of a walk-forward backtest of the recommendation strategies
+type BacktestResult struct {
+	Game        GameSpec           `json:"game"`
+	MinHistory  int                `json:"min_history"`
+	Tests       int                `json:"tests"` // Drawings scored
+	FirstTested time.Time          `json:"first_tested"`
+	LastTested  time.Time          `json:"last_tested"`
+	Baseline    BacktestBaseline   `json:"baseline"`
+	Strategies  []StrategyBacktest `json:"strategies"`
+	Spent       float64            `json:"spent,omitempty"` // Cost of one ticket per tested drawing, when prizes are known
+}
+
+// BacktestBaseline is the expected performance of a uniform random pick over the tested drawings
+type BacktestBaseline struct {
+	Hits         []float64 `json:"hits"` // Hits[k] is the expected number of drawings matching k main numbers
+	MeanHits     float64   `json:"mean_hits"`
+	BonusHitRate float64   `json:"bonus_hit_rate"`
+	Winnings     float64   `json:"winnings,omitempty"` // Expected cash won over the tested drawings, when prizes are known
+	ROI          float64   `json:"roi,omitempty"`      // Expected return on the money spent, when prizes are known
+}
+
+// StrategyBacktest is how one strategy's sets scored against the drawings they were made for
+type StrategyBacktest struct {
+	Strategy       string         `json:"strategy"`
+	Skipped        int            `json:"skipped,omitempty"` // Drawings the strategy had nothing to play for; the rest are scored
+	Hits           []int          `json:"hits"`              // Hits[k] is the number of drawings where the set matched k main numbers
+	MeanHits       float64        `json:"mean_hits"`
+	BonusHits      int            `json:"bonus_hits"`
+	BonusHitRate   float64        `json:"bonus_hit_rate"`
+	VersusBaseline ChiSquareTest  `json:"versus_baseline"`       // Goodness of fit of Hits against the baseline
+	Winnings       float64        `json:"winnings,omitempty"`    // Cash won over the tested drawings, when prizes are known
+	ROI            float64        `json:"roi,omitempty"`         // Return on the money spent, when prizes are known
+	PrizeTiers     map[string]int `json:"prize_tiers,omitempty"` // Wins by prize tier name
+}
+
+// BacktestSummary is the summary record of an NDJSON backtest
+type BacktestSummary struct {
+	Game        GameSpec         `json:"game"`
+	MinHistory  int              `json:"min_history"`
+	Tests       int              `json:"tests"`
+	FirstTested time.Time        `json:"first_tested"`
+	LastTested  time.Time        `json:"last_tested"`
+	Baseline    BacktestBaseline `json:"baseline"`
+	Spent       float64          `json:"spent,omitempty"`
+}
+
+// Backtest replays history: for each tested drawing it rebuilds the analysis from only the drawings
+// before it, generates every strategy's set and scores the set against the actual result. A strategy
+// with nothing to play for a drawing sits it out and is compared with random picks over the rest.
+func (a *Analyzer) Backtest(ctx context.Context, config BacktestConfig) (*BacktestResult, error) {
+	if config.MinHistory <= 0 {
+		config.MinHistory = DefaultBacktestMinHistory
+	}
+
+	// Drawings are newest first, so the drawings before index i are the ones after it
+	eligible := len(a.drawings) - config.MinHistory
+	if eligible <= 0 {
+		return nil, fmt.Errorf("%w: %d drawings, need more than %d", ErrInsufficientHistory, len(a.drawings), config.MinHistory)
+	}
+	tests := eligible
+	if config.MaxTests > 0 && config.MaxTests < tests {
+		tests = config.MaxTests
+	}
+
+	game := a.spec()
+	prizes, _ := a.PrizeTable() // Nil for games without known prizes
+	strategies, err := a.selectedStrategies()
+	if err != nil {
+		return nil, err
+	}
+	if err = a.constraints().Validate(game); err != nil {
+		return nil, err
+	}
+	if err = a.popularity().Validate(); err != nil {
+		return nil, err
+	}
+	result := &BacktestResult{
+		Game:        a.Game(),
+		MinHistory:  config.MinHistory,
+		Tests:       tests,
+		FirstTested: a.drawings[tests-1].Date,
+		LastTested:  a.drawings[0].Date,
+		Baseline:    BacktestBaseline{Hits: make([]float64, game.MainPicks+1)},
+		Strategies:  make([]StrategyBacktest, len(strategies)),
+	}
+	expected := make([][]float64, len(strategies)) // Random-pick hits over the drawings each strategy played
+	for i, strategy := range strategies {
+		result.Strategies[i] = StrategyBacktest{Strategy: strategy.Name(), Hits: make([]int, game.MainPicks+1)}
+		expected[i] = make([]float64, game.MainPicks+1)
+	}
+
+	rng := a.newSampler()
+	_, _ = fmt.Fprintf(a.progressWriter(), "🔁 Backtesting %d drawings...\n", tests)
+	for i := tests - 1; i >= 0; i-- {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		past, err := a.historyBefore(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		actual := a.drawings[i]
+		random := randomPickHits(game, actual.Numbers)
+		for k, p := range random {
+			result.Baseline.Hits[k] += p
+		}
+		for s, strategy := range strategies {
+			stats := &result.Strategies[s]
+			set, setErr := past.generateSet(strategy, nil, rng)
+			if errors.Is(setErr, ErrNothingToPlay) {
+				stats.Skipped++
+				continue
+			}
+			if setErr != nil {
+				return nil, fmt.Errorf("failed to generate %s set: %w", strategy.Name(), setErr)
+			}
+			for k, p := range random {
+				expected[s][k] += p
+			}
+			stats.Hits[countMatches(set.Numbers, actual.Numbers)]++
+			if game.HasBonus() && set.LuckyBall == actual.LuckyBall {
+				stats.BonusHits++
+			}
+			if prizes == nil {
+				continue
+			}
+			if won := prizes.Evaluate(set.Ticket(), actual); won.Tier != nil {
+				if stats.PrizeTiers == nil {
+					stats.PrizeTiers = make(map[string]int)
+				}
+				stats.PrizeTiers[won.Tier.Name]++
+				stats.Winnings += won.CashValue
+			}
+		}
+	}
+
+	result.Baseline.MeanHits = meanHits(result.Baseline.Hits, tests)
+	if game.HasBonus() {
+		result.Baseline.BonusHitRate = 1 / float64(game.BonusPoolSize)
+	}
+	if prizes != nil {
+		result.Spent = prizes.TicketPrice * float64(tests)
+		result.Baseline.Winnings = prizes.ExpectedValue(game) * float64(tests)
+		result.Baseline.ROI = prizes.TicketValue(game).ROI
+	}
+	for s := range result.Strategies {
+		stats := &result.Strategies[s]
+		played := tests - stats.Skipped
+		observed := make([]float64, len(stats.Hits))
+		for k, count := range stats.Hits {
+			observed[k] = float64(count)
+		}
+		stats.MeanHits = meanHits(observed, played)
+		if played > 0 {
+			stats.BonusHitRate = float64(stats.BonusHits) / float64(played)
+		}
+		stats.VersusBaseline = goodnessOfFit(observed, expected[s], a.confidenceLevel())
+		if result.Spent > 0 && played > 0 {
+			spent := prizes.TicketPrice * float64(played)
+			stats.ROI = (stats.Winnings - spent) / spent
+		}
+	}
+
+	return result, nil
+}
+
+// Records flattens the backtest into NDJSON records, starting with the summary
+func (r *BacktestResult) Records() []Record {
+	records := []Record{{
+		Type: RecordTypeBacktestSummary,
+		Data: BacktestSummary{
+			Game:        r.Game,
+			MinHistory:  r.MinHistory,
+			Tests:       r.Tests,
+			FirstTested: r.FirstTested,
+			LastTested:  r.LastTested,
+			Baseline:    r.Baseline,
+			Spent:       r.Spent,
+		},
+	}}
+	for _, stats := range r.Strategies {
+		records = append(records, Record{Type: RecordTypeBacktestStrategy, Data: stats})
+	}
+	return records
+}
+
+// historyBefore returns an analyzer built from only the drawings older than drawing idx. It skips the
+// pair, triple and quad pattern maps, which no strategy reads and which dominate the analysis time.
+func (a *Analyzer) historyBefore(ctx context.Context, idx int) (*Analyzer, error) {
+	past := newAnalyzer(a.config, a.spec())
+	past.strategies = a.strategies
+	past.skipCombinations = true
+	past.drawings = make([]Drawing, 0, len(a.drawings)-idx-1)
+	for _, drawing := range a.drawings[idx+1:] {
+		drawing.Index = len(past.drawings)
+		past.drawings = append(past.drawings, drawing)
+	}
+	if err := past.analyzeData(ctx); err != nil {
+		return nil, fmt.Errorf("failed to analyze history before drawing %d: %w", idx, err)
+	}
+	return past, nil
+}
+
+// countMatches returns how many picked numbers were drawn, matching each drawn number at most once
+func countMatches(picked, drawn []int) int {
+	return len(matchedNumbers(picked, drawn))
+}
+
+// randomPickHits returns the probability that a uniform random set of distinct numbers matches k of drawn
+func randomPickHits(game *GameSpec, drawn []int) []float64 {
+	distinct := make(map[int]bool, len(drawn))
+	for _, num := range drawn {
+		distinct[num] = true
+	}
+
+	// A random set of MainPicks distinct numbers matches a hypergeometric count of the distinct drawn numbers
+	pool, successes, picks := game.MainPoolSize, len(distinct), game.MainPicks
+	probabilities := make([]float64, picks+1)
+	for k := 0; k <= picks; k++ {
+		probabilities[k] = math.Exp(logChoose(successes, k) + logChoose(pool-successes, picks-k) - logChoose(pool, picks))
+	}
+	return probabilities
+}
+
+// logChoose returns the natural log of the binomial coefficient n choose k, or -Inf when it is zero
+func logChoose(n, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+	lgammaN, _ := math.Lgamma(float64(n + 1))
+	lgammaK, _ := math.Lgamma(float64(k + 1))
+	lgammaNK, _ := math.Lgamma(float64(n - k + 1))
+	return lgammaN - lgammaK - lgammaNK
+}
+
+// meanHits returns the average number of matches of a hit distribution over tests drawings
+func meanHits(hits []float64, tests int) float64 {
+	if tests == 0 {
+		return 0
+	}
+	total := 0.0
+	for k, count := range hits {
+		total += float64(k) * count
+	}
+	return total / float64(tests)
+}
+
+// goodnessOfFit tests observed counts against expected counts, merging the sparse high-match bins
+// until each bin expects at least backtestMinExpected drawings
+func goodnessOfFit(observed, expected []float64, confidence float64) ChiSquareTest {
+	var binObserved, binExpected []float64
+	var accObserved, accExpected float64
+	for k := len(expected) - 1; k >= 0; k-- {
+		accObserved += observed[k]
+		accExpected += expected[k]
+		if accExpected >= backtestMinExpected {
+			binObserved = append(binObserved, accObserved)
+			binExpected = append(binExpected, accExpected)
+			accObserved, accExpected = 0, 0
+		}
+	}
+	if len(binExpected) == 0 {
+		return newChiSquareTest(0, 0, confidence)
+	}
+	// Fold any sparse remainder into the last bin
+	binObserved[len(binObserved)-1] += accObserved
+	binExpected[len(binExpected)-1] += accExpected
+
+	statistic := 0.0
+	for i, exp := range binExpected {
+		diff := binObserved[i] - exp
+		statistic += diff * diff / exp
+	}
+	return newChiSquareTest(statistic, len(binExpected)-1, confidence)
+}
+
+// WriteBacktest writes a backtest result as a console table
+func WriteBacktest(w io.Writer, result *BacktestResult) error {
+	c := &consoleWriter{w: w}
+	c.section("🔁 WALK-FORWARD BACKTEST")
+	c.printf("Game: %s\n", result.Game.Name)
+	c.printf("Tested drawings: %d (%s to %s)\n", result.Tests,
+		result.FirstTested.Format("01/02/2006"), result.LastTested.Format("01/02/2006"))
+	c.printf("Each set was generated from only the earlier drawings (at least %d)\n\n", result.MinHistory)
+
+	c.println("Drawings by main numbers matched:")
+	c.printf("%-10s", "Strategy")
+	for k := range result.Baseline.Hits {
+		c.printf(" %6d", k)
+	}
+	c.printf(" %6s", "Mean")
+	if result.Game.HasBonus() {
+		c.printf(" %8s", "Bonus")
+	}
+	c.printf(" %8s\n", "p-value")
+
+	c.printf("%-10s", "random*")
+	for _, expected := range result.Baseline.Hits {
+		c.printf(" %6.1f", expected)
+	}
+	c.printf(" %6.3f", result.Baseline.MeanHits)
+	if result.Game.HasBonus() {
+		c.printf(" %7.1f%%", result.Baseline.BonusHitRate*100)
+	}
+	c.printf(" %8s\n", "-")
+
+	for _, stats := range result.Strategies {
+		c.printf("%-10s", stats.Strategy)
+		for _, count := range stats.Hits {
+			c.printf(" %6d", count)
+		}
+		c.printf(" %6.3f", stats.MeanHits)
+		if result.Game.HasBonus() {
+			c.printf(" %7.1f%%", stats.BonusHitRate*100)
+		}
+		c.printf(" %8.4f\n", stats.VersusBaseline.PValue)
+	}
+	for _, stats := range result.Strategies {
+		if stats.Skipped > 0 {
+			c.printf("%s had nothing to play for %d of the drawings and is compared with random over the other %d\n",
+				stats.Strategy, stats.Skipped, result.Tests-stats.Skipped)
+		}
+	}
+
+	if result.Spent > 0 {
+		c.printf("\nWinnings (nominal cash value, $%.2f spent per strategy that played every drawing):\n", result.Spent)
+		c.printf("%-10s %11s %8s\n", "Strategy", "Won", "ROI")
+		c.printf("%-10s $%10.2f %7.1f%%\n", "random*", result.Baseline.Winnings, result.Baseline.ROI*100)
+		for _, stats := range result.Strategies {
+			c.printf("%-10s $%10.2f %7.1f%%\n", stats.Strategy, stats.Winnings, stats.ROI*100)
+		}
+	}
+
+	c.println("\n* Expected drawings for a uniform random pick of the same size.")
+	c.println("  p-value: chi-square test of the strategy's hit distribution against random.")
+	c.println("  A strategy with real predictive power would have a higher mean and a small p-value;")
+	c.println("  in a fair lottery every strategy should look like random.")
+
+	return c.err
+}
@@ -0,0 +1,158 @@
+package lucky
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+)
+
+// TestBacktest tests that each tested drawing is scored once per strategy with something to play
+func (s *AnalyzerTestSuite) TestBacktest() {
+	ctx := context.Background()
+	s.analyzer.SetProgressWriter(nil)
+	drawings := s.analyzer.Drawings()
+
+	result, err := s.analyzer.Backtest(ctx, BacktestConfig{MinHistory: 2})
+	s.Require().NoError(err)
+	s.Equal(3, result.Tests)
+	s.Equal(2, result.MinHistory)
+	s.Equal(drawings[2].Date, result.FirstTested)
+	s.Equal(drawings[0].Date, result.LastTested)
+
+	picks := s.analyzer.Game().MainPicks
+	s.Require().Len(result.Baseline.Hits, picks+1)
+	baselineTotal := 0.0
+	for _, expected := range result.Baseline.Hits {
+		baselineTotal += expected
+	}
+	s.InDelta(3.0, baselineTotal, 1e-9)
+	s.InDelta(float64(picks*picks)/float64(s.analyzer.Game().MainPoolSize), result.Baseline.MeanHits, 1e-9)
+
+	s.Require().Len(result.Strategies, len(s.analyzer.Strategies().Names()))
+	for _, stats := range result.Strategies {
+		// Five drawings make no pair unusual, so the pattern strategy sits every drawing out
+		if stats.Strategy == "pattern" {
+			s.Equal(3, stats.Skipped)
+		} else {
+			s.Zero(stats.Skipped, stats.Strategy)
+		}
+		played := 3 - stats.Skipped
+		total := 0
+		for _, count := range stats.Hits {
+			total += count
+		}
+		s.Equal(played, total, stats.Strategy)
+		s.LessOrEqual(stats.BonusHits, 3)
+		s.GreaterOrEqual(stats.VersusBaseline.PValue, 0.0)
+
+		wins, winnings := 0, 0.0
+		prizes := luckyForLifePrizes()
+		for name, count := range stats.PrizeTiers {
+			wins += count
+			winnings += float64(count) * prizes.Tier(tierMatches(name)).CashValue
+		}
+		s.LessOrEqual(wins, 3)
+		s.InDelta(winnings, stats.Winnings, 1e-9)
+		if played > 0 {
+			s.InDelta((winnings-2*float64(played))/(2*float64(played)), stats.ROI, 1e-9)
+		} else {
+			s.Zero(stats.ROI)
+			s.Zero(stats.MeanHits)
+		}
+	}
+	s.InDelta(6.0, result.Spent, 0)
+	s.InDelta(3*luckyForLifePrizes().ExpectedValue(luckyForLifeSpec()), result.Baseline.Winnings, 1e-9)
+	s.InDelta(result.Baseline.Winnings/6-1, result.Baseline.ROI, 1e-9)
+
+	// MaxTests keeps the most recent drawings
+	result, err = s.analyzer.Backtest(ctx, BacktestConfig{MinHistory: 2, MaxTests: 1})
+	s.Require().NoError(err)
+	s.Equal(1, result.Tests)
+	s.Equal(drawings[0].Date, result.FirstTested)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteBacktest(&buf, result))
+	s.Contains(buf.String(), "WALK-FORWARD BACKTEST")
+	s.Contains(buf.String(), "random*")
+	s.Contains(buf.String(), "pattern had nothing to play for 1 of the drawings")
+	records := result.Records()
+	s.Require().Len(records, 1+len(result.Strategies))
+	s.Equal(RecordTypeBacktestSummary, records[0].Type)
+	s.Equal(RecordTypeBacktestStrategy, records[1].Type)
+}
+
+// TestBacktestErrors tests backtests without enough history or after cancellation
+func (s *AnalyzerTestSuite) TestBacktestErrors() {
+	_, err := s.analyzer.Backtest(context.Background(), BacktestConfig{})
+	s.Require().ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = s.analyzer.Backtest(context.Background(), BacktestConfig{MinHistory: 5})
+	s.Require().ErrorIs(err, ErrInsufficientHistory)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.analyzer.SetProgressWriter(nil)
+	_, err = s.analyzer.Backtest(ctx, BacktestConfig{MinHistory: 1})
+	s.Require().ErrorIs(err, context.Canceled)
+}
+
+// TestHistoryBefore tests that a rebuilt analyzer sees only the older drawings
+func (s *AnalyzerTestSuite) TestHistoryBefore() {
+	past, err := s.analyzer.historyBefore(context.Background(), 1)
+	s.Require().NoError(err)
+
+	drawings := s.analyzer.Drawings()
+	s.Require().Len(past.drawings, len(drawings)-2)
+	for i, drawing := range past.drawings {
+		s.Equal(i, drawing.Index)
+		s.Equal(drawings[i+2].Date, drawing.Date)
+	}
+	total := 0
+	for _, info := range past.mainNumbers {
+		total += info.TotalFrequency
+	}
+	s.Equal(len(past.drawings)*s.analyzer.Game().MainPicks, total)
+
+	// No strategy reads the combination maps, so the history skips them
+	s.Empty(past.pairPatterns)
+	s.Empty(past.quadPatterns)
+	s.NotEmpty(s.analyzer.pairPatterns)
+}
+
+// TestBacktestScoring tests match counting, the random baseline and the goodness-of-fit test
+func (s *AnalyzerTestSuite) TestBacktestScoring() {
+	s.Equal(2, countMatches([]int{5, 12, 23}, []int{12, 5, 40}))
+	s.Equal(1, countMatches([]int{7, 7}, []int{7, 1, 2})) // Each drawn number matches once
+	s.Equal(0, countMatches(nil, []int{1}))
+
+	// Lucky for Life: P(no matches) = C(43,5)/C(48,5)
+	game := luckyForLifeSpec()
+	hits := randomPickHits(game, []int{1, 2, 3, 4, 5})
+	s.InDelta(962598.0/1712304.0, hits[0], 1e-12)
+	s.InDelta(1.0/1712304.0, hits[5], 1e-15)
+	total := 0.0
+	for _, p := range hits {
+		total += p
+	}
+	s.InDelta(1.0, total, 1e-12)
+
+	s.InDelta(math.Log(10), logChoose(5, 2), 1e-12)
+	s.True(math.IsInf(logChoose(3, 4), -1))
+
+	// Matching the expectation exactly is a perfect fit; sparse bins are merged
+	expected := []float64{60, 30, 8, 1.5, 0.5}
+	test := goodnessOfFit(expected, expected, 0.95)
+	s.InDelta(0.0, test.Statistic, 1e-12)
+	s.Equal(2, test.DegreesOfFreedom) // {0}, {1}, {2,3,4}
+	s.InDelta(1.0, test.PValue, 1e-12)
+
+	test = goodnessOfFit([]float64{40, 40, 20, 0, 0}, expected, 0.95)
+	s.True(test.RejectsUniform)
+	s.Zero(goodnessOfFit([]float64{1}, []float64{1}, 0.95).DegreesOfFreedom)
+}
+
+// tierMatches parses a prize tier name such as "3+LB" into its match counts
+func tierMatches(name string) (int, bool) {
+	return int(name[0] - '0'), strings.HasSuffix(name, "+LB")
+}
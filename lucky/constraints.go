@@ -0,0 +1,405 @@
+package lucky
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConstraints indicates ticket constraints that contradict themselves or the game
+var ErrInvalidConstraints = errors.New("invalid ticket constraints")
+
+// ErrUnsatisfiableConstraints indicates that no ticket a strategy can pick meets the constraints
+var ErrUnsatisfiableConstraints = errors.New("no ticket satisfies the constraints")
+
+// ErrConstraintViolated indicates a ticket that breaks one of the constraints
+var ErrConstraintViolated = errors.New("ticket violates constraints")
+
+// maxConstraintSearch caps how many partial tickets the constrained search visits
+const maxConstraintSearch = 5_000_000
+
+// ticketScoringCandidates is how many tickets meeting the constraints a TicketScorer strategy chooses from
+const ticketScoringCandidates = 200
+
+// Constraint names, used to report which constraint rejected the most tickets
+const (
+	constraintSum         = "sum range"
+	constraintOddEven     = "odd/even split"
+	constraintConsecutive = "consecutive run"
+	constraintDecades     = "decade spread"
+	constraintPerDecade   = "numbers per decade"
+	constraintInclude     = "include list"
+	constraintExclude     = "exclude list"
+	constraintPast        = "past drawings"
+)
+
+// TicketConstraints rejects generated tickets that look "unnatural". Zero values impose nothing.
+type TicketConstraints struct {
+	MinSum         int      `json:"min_sum,omitempty"`
+	MaxSum         int      `json:"max_sum,omitempty"`
+	OddEven        []string `json:"odd_even,omitempty"`         // Allowed splits, labeled like the odd/even patterns, e.g. "3O-2E"
+	MaxConsecutive int      `json:"max_consecutive,omitempty"`  // Longest allowed run of consecutive numbers
+	MinDecades     int      `json:"min_decades,omitempty"`      // Fewest decade buckets the numbers must span
+	MaxPerDecade   int      `json:"max_per_decade,omitempty"`   // Most numbers allowed in one decade bucket
+	Include        []int    `json:"include,omitempty"`          // Numbers every ticket must contain
+	Exclude        []int    `json:"exclude,omitempty"`          // Numbers no ticket may contain
+	NoPastDrawings bool     `json:"no_past_drawings,omitempty"` // Reject main numbers identical to a past drawing
+}
+
+// constraints returns the configured ticket constraints, nil when there are none
+func (a *Analyzer) constraints() *TicketConstraints {
+	if a.config == nil {
+		return nil
+	}
+	return a.config.Constraints
+}
+
+// ReadTicketConstraints decodes constraints from JSON, rejecting unknown fields so typos are not ignored
+func ReadTicketConstraints(r io.Reader) (*TicketConstraints, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var constraints TicketConstraints
+	if err := decoder.Decode(&constraints); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConstraints, err)
+	}
+	return &constraints, nil
+}
+
+// IsZero reports whether the constraints impose nothing
+func (c *TicketConstraints) IsZero() bool {
+	return c == nil || (c.MinSum == 0 && c.MaxSum == 0 && len(c.OddEven) == 0 && c.MaxConsecutive == 0 &&
+		c.MinDecades == 0 && c.MaxPerDecade == 0 && len(c.Include) == 0 && len(c.Exclude) == 0 && !c.NoPastDrawings)
+}
+
+// Validate checks that the constraints make sense for the game
+func (c *TicketConstraints) Validate(game *GameSpec) error {
+	if c.IsZero() {
+		return nil
+	}
+	switch {
+	case c.MinSum < 0 || c.MaxSum < 0:
+		return fmt.Errorf("%w: sums must not be negative", ErrInvalidConstraints)
+	case c.MaxSum > 0 && c.MinSum > c.MaxSum:
+		return fmt.Errorf("%w: minimum sum %d is above maximum sum %d", ErrInvalidConstraints, c.MinSum, c.MaxSum)
+	case c.MaxConsecutive < 0 || c.MaxPerDecade < 0:
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidConstraints)
+	case c.MinDecades < 0 || c.MinDecades > game.MainPicks:
+		return fmt.Errorf("%w: %d numbers cannot span %d decades", ErrInvalidConstraints, game.MainPicks, c.MinDecades)
+	case len(c.Include) > game.MainPicks:
+		return fmt.Errorf("%w: %d included numbers do not fit a %d-number ticket", ErrInvalidConstraints, len(c.Include), game.MainPicks)
+	}
+	for _, label := range c.OddEven {
+		odd, even, ok := parseOddEvenLabel(label)
+		if !ok || odd+even != game.MainPicks {
+			return fmt.Errorf("%w: odd/even split %q must look like %s", ErrInvalidConstraints, label,
+				oddEvenLabel(make([]int, game.MainPicks)))
+		}
+	}
+
+	excluded := make(map[int]bool, len(c.Exclude))
+	for _, num := range c.Exclude {
+		if !game.ValidMain(num) {
+			return fmt.Errorf("%w: excluded number %d is outside %d-%d", ErrInvalidConstraints, num, game.MinNumber, game.MaxNumber())
+		}
+		excluded[num] = true
+	}
+	included := make(map[int]bool, len(c.Include))
+	for _, num := range c.Include {
+		switch {
+		case !game.ValidMain(num):
+			return fmt.Errorf("%w: included number %d is outside %d-%d", ErrInvalidConstraints, num, game.MinNumber, game.MaxNumber())
+		case excluded[num]:
+			return fmt.Errorf("%w: %d is both included and excluded", ErrInvalidConstraints, num)
+		case included[num]:
+			return fmt.Errorf("%w: %d is included twice", ErrInvalidConstraints, num)
+		}
+		included[num] = true
+	}
+	return nil
+}
+
+// Check reports the first constraint a ticket's main numbers break, comparing them against history
+func (c *TicketConstraints) Check(game *GameSpec, numbers []int, history []Drawing) error {
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+	if _, reason := c.violation(game, sorted, true, pastTickets(history)); reason != "" {
+		return fmt.Errorf("%w: %s", ErrConstraintViolated, reason)
+	}
+	return nil
+}
+
+// String describes the active constraints
+func (c *TicketConstraints) String() string {
+	if c.IsZero() {
+		return "no constraints"
+	}
+	var parts []string
+	switch {
+	case c.MaxSum > 0:
+		parts = append(parts, fmt.Sprintf("sum %d-%d", c.MinSum, c.MaxSum))
+	case c.MinSum > 0:
+		parts = append(parts, fmt.Sprintf("sum at least %d", c.MinSum))
+	}
+	if len(c.OddEven) > 0 {
+		parts = append(parts, "odd/even "+strings.Join(c.OddEven, " or "))
+	}
+	if c.MaxConsecutive > 0 {
+		parts = append(parts, fmt.Sprintf("at most %d consecutive", c.MaxConsecutive))
+	}
+	if c.MinDecades > 0 {
+		parts = append(parts, fmt.Sprintf("at least %d decades", c.MinDecades))
+	}
+	if c.MaxPerDecade > 0 {
+		parts = append(parts, fmt.Sprintf("at most %d per decade", c.MaxPerDecade))
+	}
+	if len(c.Include) > 0 {
+		parts = append(parts, "include "+joinInts(c.Include))
+	}
+	if len(c.Exclude) > 0 {
+		parts = append(parts, "exclude "+joinInts(c.Exclude))
+	}
+	if c.NoPastDrawings {
+		parts = append(parts, "no past drawings")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pickConstrained searches the ranking best first for main numbers that meet the constraints: the
+// included numbers plus the best-ranked combination of the rest. Numbers in avoid are tried last.
+// With scoreTicket set, the first ticketScoringCandidates tickets that meet the constraints are
+// scored and the best-scored one wins, ties going to the best-ranked. past indexes past drawings by
+// their sorted main numbers. Too few candidate numbers to fill a ticket returns what there is.
+func (c *TicketConstraints) pickConstrained(game *GameSpec, ranked []ScoredNumber, avoid map[int]bool,
+	past map[string]string, scoreTicket func(numbers []int) float64,
+) ([]int, error) {
+	skip := make(map[int]bool, len(c.Include)+len(c.Exclude))
+	for _, num := range append(append([]int(nil), c.Include...), c.Exclude...) {
+		skip[num] = true
+	}
+	candidates := pickScored(ranked, len(ranked), func(num int) bool { return game.ValidMain(num) && !skip[num] }, avoid)
+	picked := append(make([]int, 0, game.MainPicks), c.Include...)
+	if len(picked)+len(candidates) < game.MainPicks {
+		return append(picked, candidates...), nil
+	}
+
+	search := &constraintSearch{
+		constraints: c,
+		game:        game,
+		candidates:  candidates,
+		past:        past,
+		scoreTicket: scoreTicket,
+		rejections:  make(map[string]int),
+	}
+	search.extend(picked, 0)
+	if search.best != nil {
+		return search.best, nil
+	}
+
+	reason := ""
+	if busiest := search.busiestRejection(); busiest != "" {
+		reason = fmt.Sprintf(" (most often rejected by the %s)", busiest)
+	}
+	if search.visited >= maxConstraintSearch {
+		reason += fmt.Sprintf(" (gave up after %d candidates)", search.visited)
+	}
+	return nil, fmt.Errorf("%w: %s among %d candidate numbers%s", ErrUnsatisfiableConstraints, c, len(candidates), reason)
+}
+
+// constraintSearch is the state of a depth-first search for a constrained ticket
+type constraintSearch struct {
+	constraints *TicketConstraints
+	game        *GameSpec
+	candidates  []int
+	past        map[string]string
+	scoreTicket func(numbers []int) float64 // Nil takes the first ticket found
+	rejections  map[string]int
+	visited     int
+	found       int
+	best        []int
+	bestScore   float64
+}
+
+// extend adds candidates from index start onward to picked until the ticket is full and valid,
+// returning true once the search is done
+func (s *constraintSearch) extend(picked []int, start int) bool {
+	s.visited++
+	complete := len(picked) == s.game.MainPicks
+	sorted := append([]int(nil), picked...)
+	sort.Ints(sorted)
+	if name, _ := s.constraints.violation(s.game, sorted, complete, s.past); name != "" {
+		s.rejections[name]++
+		return false
+	}
+	if complete {
+		return s.accept(sorted)
+	}
+	for i := start; i < len(s.candidates) && s.visited < maxConstraintSearch; i++ {
+		if len(s.candidates)-i < s.game.MainPicks-len(picked) {
+			break // Too few candidates left to fill the ticket
+		}
+		if s.extend(append(picked, s.candidates[i]), i+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// accept records a ticket that meets the constraints, returning true once the search is done
+func (s *constraintSearch) accept(numbers []int) bool {
+	if s.scoreTicket == nil {
+		s.best = numbers
+		return true
+	}
+	if score := s.scoreTicket(numbers); s.best == nil || score > s.bestScore {
+		s.best, s.bestScore = numbers, score
+	}
+	s.found++
+	return s.found >= ticketScoringCandidates
+}
+
+// busiestRejection returns the constraint that rejected the most tickets
+func (s *constraintSearch) busiestRejection() string {
+	busiest := ""
+	for name, count := range s.rejections {
+		if count > s.rejections[busiest] || (count == s.rejections[busiest] && name < busiest) {
+			busiest = name
+		}
+	}
+	return busiest
+}
+
+// violation returns the name and description of the first constraint sorted numbers break. A partial
+// ticket is only rejected by constraints that adding numbers cannot repair.
+func (c *TicketConstraints) violation(game *GameSpec, sorted []int, complete bool, past map[string]string) (string, string) {
+	sum := 0
+	for _, num := range sorted {
+		sum += num
+	}
+	if c.MaxSum > 0 && sum > c.MaxSum {
+		return constraintSum, fmt.Sprintf("sum %d is above %d", sum, c.MaxSum)
+	}
+	if sum+(game.MainPicks-len(sorted))*game.MaxNumber() < c.MinSum {
+		return constraintSum, fmt.Sprintf("sum %d is below %d", sum, c.MinSum)
+	}
+	if run := longestRun(sorted); c.MaxConsecutive > 0 && run > c.MaxConsecutive {
+		return constraintConsecutive, fmt.Sprintf("run of %d consecutive numbers is longer than %d", run, c.MaxConsecutive)
+	}
+	perDecade := make(map[int]int, len(sorted))
+	for _, num := range sorted {
+		perDecade[decadeBucket(game, num)]++
+		if c.MaxPerDecade > 0 && perDecade[decadeBucket(game, num)] > c.MaxPerDecade {
+			return constraintPerDecade, fmt.Sprintf("more than %d numbers in the %d-%d decade", c.MaxPerDecade,
+				game.MinNumber+decadeBucket(game, num)*10, game.MinNumber+decadeBucket(game, num)*10+9)
+		}
+	}
+	if len(c.OddEven) > 0 && !c.oddEvenReachable(sorted, game.MainPicks) {
+		return constraintOddEven, fmt.Sprintf("odd/even split %s is not %s", oddEvenLabel(sorted), strings.Join(c.OddEven, " or "))
+	}
+	if !complete {
+		return "", ""
+	}
+
+	if len(perDecade) < c.MinDecades {
+		return constraintDecades, fmt.Sprintf("spans %d decades, needs %d", len(perDecade), c.MinDecades)
+	}
+	drawn := make(map[int]bool, len(sorted))
+	for _, num := range sorted {
+		drawn[num] = true
+	}
+	for _, num := range c.Include {
+		if !drawn[num] {
+			return constraintInclude, fmt.Sprintf("%d is missing", num)
+		}
+	}
+	for _, num := range c.Exclude {
+		if drawn[num] {
+			return constraintExclude, fmt.Sprintf("%d is excluded", num)
+		}
+	}
+	if date, ok := past[joinInts(sorted)]; ok && c.NoPastDrawings {
+		return constraintPast, "identical to the " + date + " drawing"
+	}
+	return "", ""
+}
+
+// oddEvenReachable reports whether numbers can still grow into one of the allowed odd/even splits
+func (c *TicketConstraints) oddEvenReachable(numbers []int, picks int) bool {
+	odd := 0
+	for _, num := range numbers {
+		odd += num % 2
+	}
+	even := len(numbers) - odd
+	for _, label := range c.OddEven {
+		wantOdd, wantEven, _ := parseOddEvenLabel(label)
+		if odd <= wantOdd && even <= wantEven && wantOdd+wantEven == picks {
+			return true
+		}
+	}
+	return false
+}
+
+// oddEvenLabel labels the odd/even split of numbers the way the pattern analysis does, e.g. "3O-2E"
+func oddEvenLabel(numbers []int) string {
+	odd := 0
+	for _, num := range numbers {
+		odd += num % 2
+	}
+	return fmt.Sprintf("%dO-%dE", odd, len(numbers)-odd)
+}
+
+// parseOddEvenLabel parses an odd/even split label such as "3O-2E"
+func parseOddEvenLabel(label string) (int, int, bool) {
+	oddText, evenText, found := strings.Cut(strings.ToUpper(strings.TrimSpace(label)), "O-")
+	if !found || !strings.HasSuffix(evenText, "E") {
+		return 0, 0, false
+	}
+	odd, oddErr := strconv.Atoi(oddText)
+	even, evenErr := strconv.Atoi(strings.TrimSuffix(evenText, "E"))
+	if oddErr != nil || evenErr != nil || odd < 0 || even < 0 {
+		return 0, 0, false
+	}
+	return odd, even, true
+}
+
+// decadeBucket returns the decade bucket of a number, as counted in the pattern analysis
+func decadeBucket(game *GameSpec, num int) int {
+	return (num - game.MinNumber) / 10
+}
+
+// longestRun returns the length of the longest run of consecutive numbers in sorted numbers
+func longestRun(sorted []int) int {
+	longest, run := 0, 0
+	for i, num := range sorted {
+		if i > 0 && num-sorted[i-1] == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// pastTickets indexes the main numbers of past drawings by their sorted numbers, with the drawing date
+func pastTickets(history []Drawing) map[string]string {
+	past := make(map[string]string, len(history))
+	for _, drawing := range history {
+		sorted := append([]int(nil), drawing.Numbers...)
+		sort.Ints(sorted)
+		past[joinInts(sorted)] = drawing.Date.Format("01/02/2006")
+	}
+	return past
+}
+
+// joinInts joins numbers with spaces
+func joinInts(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, num := range numbers {
+		parts[i] = strconv.Itoa(num)
+	}
+	return strings.Join(parts, " ")
+}
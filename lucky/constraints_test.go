@@ -0,0 +1,125 @@
+package lucky
+
+import (
+	"context"
+	"strings"
+)
+
+// TestTicketConstraintsValidate tests rejecting constraints that contradict themselves or the game
+func (s *AnalyzerTestSuite) TestTicketConstraintsValidate() {
+	game := s.analyzer.spec()
+	s.Require().NoError((*TicketConstraints)(nil).Validate(game))
+	s.Require().NoError((&TicketConstraints{MinSum: 100, MaxSum: 150, OddEven: []string{"3o-2e"}, Include: []int{7}}).Validate(game))
+
+	testCases := []struct {
+		name        string
+		constraints TicketConstraints
+	}{
+		{"inverted sum", TicketConstraints{MinSum: 150, MaxSum: 100}},
+		{"negative sum", TicketConstraints{MinSum: -1}},
+		{"malformed split", TicketConstraints{OddEven: []string{"three odd"}}},
+		{"split of the wrong size", TicketConstraints{OddEven: []string{"3O-3E"}}},
+		{"too many decades", TicketConstraints{MinDecades: 6}},
+		{"negative run", TicketConstraints{MaxConsecutive: -1}},
+		{"included out of range", TicketConstraints{Include: []int{49}}},
+		{"excluded out of range", TicketConstraints{Exclude: []int{0}}},
+		{"included and excluded", TicketConstraints{Include: []int{7}, Exclude: []int{7}}},
+		{"included twice", TicketConstraints{Include: []int{7, 7}}},
+		{"too many included", TicketConstraints{Include: []int{1, 2, 3, 4, 5, 6}}},
+	}
+	for _, tc := range testCases {
+		s.Require().ErrorIs(tc.constraints.Validate(game), ErrInvalidConstraints, "Test case: %s", tc.name)
+	}
+
+	_, err := ReadTicketConstraints(strings.NewReader(`{"max_sums": 150}`))
+	s.Require().ErrorIs(err, ErrInvalidConstraints)
+	constraints, err := ReadTicketConstraints(strings.NewReader(`{"min_sum": 100, "odd_even": ["3O-2E"], "no_past_drawings": true}`))
+	s.Require().NoError(err)
+	s.Equal("sum at least 100, odd/even 3O-2E, no past drawings", constraints.String())
+}
+
+// TestTicketConstraintsCheck tests each constraint against single tickets
+func (s *AnalyzerTestSuite) TestTicketConstraintsCheck() {
+	game := s.analyzer.spec()
+	history := s.analyzer.Drawings()
+	testCases := []struct {
+		name        string
+		constraints TicketConstraints
+		numbers     []int
+		reason      string
+	}{
+		{"sum too high", TicketConstraints{MaxSum: 100}, []int{10, 20, 30, 40, 1}, "sum 101 is above 100"},
+		{"sum too low", TicketConstraints{MinSum: 100}, []int{1, 2, 3, 4, 5}, "sum 15 is below 100"},
+		{"odd/even split", TicketConstraints{OddEven: []string{"3O-2E"}}, []int{1, 3, 5, 7, 8}, "4O-1E is not 3O-2E"},
+		{"consecutive run", TicketConstraints{MaxConsecutive: 2}, []int{4, 2, 3, 20, 30}, "run of 3"},
+		{"decade spread", TicketConstraints{MinDecades: 3}, []int{1, 2, 11, 12, 13}, "spans 2 decades, needs 3"},
+		{"per decade", TicketConstraints{MaxPerDecade: 2}, []int{1, 2, 11, 12, 13}, "more than 2 numbers in the 11-20 decade"},
+		{"missing include", TicketConstraints{Include: []int{7}}, []int{1, 2, 11, 12, 13}, "7 is missing"},
+		{"excluded", TicketConstraints{Exclude: []int{13}}, []int{1, 2, 11, 12, 13}, "13 is excluded"},
+		{"past drawing", TicketConstraints{NoPastDrawings: true}, []int{45, 34, 23, 12, 5}, "identical to the 01/15/2024 drawing"},
+	}
+	for _, tc := range testCases {
+		err := tc.constraints.Check(game, tc.numbers, history)
+		s.Require().ErrorIs(err, ErrConstraintViolated, "Test case: %s", tc.name)
+		s.Contains(err.Error(), tc.reason, "Test case: %s", tc.name)
+	}
+
+	constraints := TicketConstraints{MinSum: 100, MaxSum: 150, OddEven: []string{"3O-2E"}, MaxConsecutive: 1, MinDecades: 4, NoPastDrawings: true}
+	s.Require().NoError(constraints.Check(game, []int{7, 15, 28, 33, 40}, history))
+}
+
+// TestConstrainedRecommendations tests that every recommended set meets the configured constraints
+func (s *AnalyzerTestSuite) TestConstrainedRecommendations() {
+	ctx := context.Background()
+	constraints := &TicketConstraints{
+		MinSum:         90,
+		MaxSum:         140,
+		OddEven:        []string{"3O-2E", "2O-3E"},
+		MaxConsecutive: 1,
+		MinDecades:     3,
+		Include:        []int{7},
+		Exclude:        []int{5, 23},
+		NoPastDrawings: true,
+	}
+	s.analyzer.config.Constraints = constraints
+
+	sets, err := s.analyzer.GenerateRecommendations(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 10)
+	for _, set := range sets {
+		s.Require().NoError(constraints.Check(s.analyzer.spec(), set.Numbers, s.analyzer.Drawings()), "Set %v (%s)", set.Numbers, set.Strategy)
+	}
+
+	// The sampled picks meet them too
+	s.analyzer.config.Temperature, s.analyzer.config.Seed = 1, 3
+	sets, err = s.analyzer.GenerateRecommendations(ctx, 5)
+	s.Require().NoError(err)
+	for _, set := range sets {
+		s.Require().NoError(constraints.Check(s.analyzer.spec(), set.Numbers, s.analyzer.Drawings()), "Set %v (%s)", set.Numbers, set.Strategy)
+	}
+
+	// 44+45+46+47+48 = 230 is the largest possible sum
+	s.analyzer.config.Constraints = &TicketConstraints{MinSum: 231, MaxSum: 300}
+	_, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().ErrorIs(err, ErrUnsatisfiableConstraints)
+	s.Contains(err.Error(), "balanced")
+	s.Contains(err.Error(), "most often rejected by the sum range")
+
+	s.analyzer.config.Constraints = &TicketConstraints{MinDecades: 9}
+	_, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().ErrorIs(err, ErrInvalidConstraints)
+}
+
+// TestPatternLabels tests the odd/even labels, decade buckets and runs shared with the pattern analysis
+func (s *AnalyzerTestSuite) TestPatternLabels() {
+	s.Equal("3O-2E", oddEvenLabel([]int{5, 12, 23, 34, 45}))
+	odd, even, ok := parseOddEvenLabel(" 2o-3e ")
+	s.True(ok)
+	s.Equal([]int{2, 3}, []int{odd, even})
+	_, _, ok = parseOddEvenLabel("2O3E")
+	s.False(ok)
+
+	game := s.analyzer.spec()
+	s.Equal([]int{0, 0, 1, 4}, []int{decadeBucket(game, 1), decadeBucket(game, 10), decadeBucket(game, 11), decadeBucket(game, 48)})
+	s.Equal([]int{0, 1, 3}, []int{longestRun(nil), longestRun([]int{1, 3, 5}), longestRun([]int{1, 2, 3, 7, 8})})
+}
@@ -1,11 +1,13 @@
-package main
+package lucky
 
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -60,14 +62,51 @@ type CorrelationEngine struct {
 
 // CorrelationResult represents a correlation between a factor and lottery outcomes
 type CorrelationResult struct {
-	Factor            string                 `json:"factor"`
-	SubFactor         string                 `json:"sub_factor,omitempty"`
-	Correlation       float64                `json:"correlation"`
-	PValue            float64                `json:"p_value"`
-	SampleSize        int                    `json:"sample_size"`
-	Significance      string                 `json:"significance"`
-	Interpretation    string                 `json:"interpretation"`
-	VisualizationData map[string]interface{} `json:"visualization_data,omitempty"`
+	Factor             string                 `json:"factor"`
+	SubFactor          string                 `json:"sub_factor,omitempty"`
+	Correlation        float64                `json:"correlation"`
+	PValue             float64                `json:"p_value"`
+	ConfidenceInterval *ConfidenceInterval    `json:"confidence_interval,omitempty"` // Fisher z interval of Correlation
+	AdjustedPValues    AdjustedPValues        `json:"adjusted_p_values"`             // PValue corrected for every test in the run
+	SampleSize         int                    `json:"sample_size"`
+	Significance       string                 `json:"significance"`
+	Interpretation     string                 `json:"interpretation"`
+	VisualizationData  map[string]interface{} `json:"visualization_data,omitempty"`
+}
+
+// spec returns the game rules of the analyzed data, defaulting to Lucky for Life
+func (ce *CorrelationEngine) spec() *GameSpec {
+	if ce.analyzer == nil {
+		return luckyForLifeSpec()
+	}
+	return ce.analyzer.spec()
+}
+
+// progressWriter returns where progress and warning messages are written
+func (ce *CorrelationEngine) progressWriter() io.Writer {
+	if ce.analyzer == nil {
+		return os.Stderr
+	}
+	return ce.analyzer.progressWriter()
+}
+
+// CorrelationResults returns a copy of the results of the last AnalyzeCorrelations run
+func (ce *CorrelationEngine) CorrelationResults() []CorrelationResult {
+	return append([]CorrelationResult(nil), ce.correlationResults...)
+}
+
+// MultipleComparisons summarizes how many of the last run's results survive multiple-comparison correction
+func (ce *CorrelationEngine) MultipleComparisons() MultipleComparisons {
+	return SummarizeMultipleComparisons(ce.correlationResults, significanceLevel(ce.confidenceLevel()))
+}
+
+// CosmicData returns the cosmic conditions recorded for a drawing date, if any
+func (ce *CorrelationEngine) CosmicData(date time.Time) (CosmicData, bool) {
+	cosmic, ok := ce.cosmicData[date.Format(dateFormatISO)]
+	if !ok {
+		return CosmicData{}, false
+	}
+	return *cosmic, true
 }
 
 // NewCorrelationEngine creates a new correlation analysis engine
@@ -83,7 +122,7 @@ func NewCorrelationEngine(analyzer *Analyzer) *CorrelationEngine {
 
 // EnrichWithCosmicData fetches and associates cosmic data with lottery drawings
 func (ce *CorrelationEngine) EnrichWithCosmicData(ctx context.Context) error { //nolint:unparam // error return may be used in future
-	_, _ = fmt.Fprintln(os.Stdout, "\n🌌 Fetching Cosmic Data...")
+	_, _ = fmt.Fprintln(ce.progressWriter(), "\n🌌 Fetching Cosmic Data...")
 
 	// Get unique years from drawings
 	yearMap := make(map[int]bool)
@@ -94,7 +133,7 @@ func (ce *CorrelationEngine) EnrichWithCosmicData(ctx context.Context) error { /
 	// Fetch moon phase data for each year
 	for year := range yearMap {
 		if err := ce.fetchMoonPhaseData(ctx, year); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Warning: Could not fetch moon data for %d: %v\n", year, err)
+			_, _ = fmt.Fprintf(ce.progressWriter(), "Warning: Could not fetch moon data for %d: %v\n", year, err)
 		}
 	}
 
@@ -117,7 +156,7 @@ func (ce *CorrelationEngine) EnrichWithCosmicData(ctx context.Context) error { /
 		ce.addMockDataForDemo(cosmic)
 	}
 
-	_, _ = fmt.Fprintf(os.Stdout, "✅ Enriched %d drawings with cosmic data\n", len(ce.cosmicData))
+	_, _ = fmt.Fprintf(ce.progressWriter(), "✅ Enriched %d drawings with cosmic data\n", len(ce.cosmicData))
 	return nil
 }
 
@@ -357,7 +396,7 @@ func (ce *CorrelationEngine) addMockDataForDemo(cosmic *CosmicData) {
 
 // AnalyzeCorrelations performs correlation analysis between cosmic factors and lottery outcomes
 func (ce *CorrelationEngine) AnalyzeCorrelations(_ context.Context) error { //nolint:unparam // error return may be used in future
-	_, _ = fmt.Fprintln(os.Stdout, "\n🔬 Analyzing Cosmic Correlations...")
+	_, _ = fmt.Fprintln(ce.progressWriter(), "\n🔬 Analyzing Cosmic Correlations...")
 
 	ce.correlationResults = []CorrelationResult{}
 
@@ -376,7 +415,10 @@ func (ce *CorrelationEngine) AnalyzeCorrelations(_ context.Context) error { //no
 	// Analyze planetary correlations
 	ce.analyzePlanetaryCorrelations()
 
-	_, _ = fmt.Fprintf(os.Stdout, "✅ Completed %d correlation analyses\n", len(ce.correlationResults))
+	// Every result above is one test of the same "cosmic influence" hypothesis
+	adjustCorrelationPValues(ce.correlationResults)
+
+	_, _ = fmt.Fprintf(ce.progressWriter(), "✅ Completed %d correlation analyses\n", len(ce.correlationResults))
 	return nil
 }
 
@@ -402,18 +444,8 @@ func (ce *CorrelationEngine) analyzeMoonPhaseCorrelations() {
 		}
 	}
 
-	// Calculate correlation
-	corr, pValue := calculatePearsonCorrelation(moonPhases, numberFrequencies)
-
-	ce.correlationResults = append(ce.correlationResults, CorrelationResult{
-		Factor:         "Moon Phase",
-		SubFactor:      "Average Number Value",
-		Correlation:    corr,
-		PValue:         pValue,
-		SampleSize:     len(moonPhases),
-		Significance:   getSignificanceLevel(pValue),
-		Interpretation: interpretMoonCorrelation(corr, pValue),
-	})
+	ce.correlationResults = append(ce.correlationResults,
+		ce.pearsonResult("Moon Phase", "Average Number Value", moonPhases, numberFrequencies, interpretMoonCorrelation))
 
 	// Analyze specific moon phases
 	ce.analyzeSpecificMoonPhases()
@@ -456,13 +488,21 @@ func (ce *CorrelationEngine) analyzeSpecificMoonPhases() {
 				}
 			}
 
+			// Is this phase's favorite number more dominant than the favorite of a random set of drawings?
+			phaseName := phase
+			test := RunPermutationTest(ce, PermutationTest[[]int]{
+				Factor:    indicator(func(cosmic *CosmicData) bool { return cosmic.MoonPhaseName == phaseName }),
+				Outcome:   drawnNumbers,
+				Statistic: topNumberShare,
+			})
+
 			ce.correlationResults = append(ce.correlationResults, CorrelationResult{
 				Factor:       "Moon Phase",
 				SubFactor:    phase + " Lucky Numbers",
 				Correlation:  float64(maxFreq) / float64(len(numbers)),
-				PValue:       0.05, // Simplified for demo
+				PValue:       test.PValue,
 				SampleSize:   len(numbers),
-				Significance: "Moderate",
+				Significance: getSignificanceLevel(test.PValue),
 				Interpretation: fmt.Sprintf("Number %d appears %.1f%% more frequently during %s",
 					mostCommon, (float64(maxFreq)/float64(len(numbers)))*100, phase),
 			})
@@ -473,35 +513,20 @@ func (ce *CorrelationEngine) analyzeSpecificMoonPhases() {
 // analyzeSolarActivityCorrelations analyzes solar activity correlations
 func (ce *CorrelationEngine) analyzeSolarActivityCorrelations() {
 	var solarWindSpeeds []float64
-	var highNumbers []float64 // Count of numbers > 30
+	var highNumbers []float64 // Count of numbers above the high threshold (30 for 5/48)
+	highThreshold := ce.spec().highThreshold()
 
 	for _, drawing := range ce.analyzer.drawings {
 		dateKey := drawing.Date.Format(dateFormatISO)
 		if cosmic, exists := ce.cosmicData[dateKey]; exists && cosmic.SolarActivity != nil {
 			solarWindSpeeds = append(solarWindSpeeds, cosmic.SolarActivity.SolarWindSpeed)
 
-			// Count high numbers
-			highCount := 0
-			for _, num := range drawing.Numbers {
-				if num > 30 {
-					highCount++
-				}
-			}
-			highNumbers = append(highNumbers, float64(highCount))
+			highNumbers = append(highNumbers, float64(countAbove(drawing.Numbers, highThreshold)))
 		}
 	}
 
-	corr, pValue := calculatePearsonCorrelation(solarWindSpeeds, highNumbers)
-
-	ce.correlationResults = append(ce.correlationResults, CorrelationResult{
-		Factor:         "Solar Activity",
-		SubFactor:      "Solar Wind vs High Numbers",
-		Correlation:    corr,
-		PValue:         pValue,
-		SampleSize:     len(solarWindSpeeds),
-		Significance:   getSignificanceLevel(pValue),
-		Interpretation: interpretSolarCorrelation(corr, pValue),
-	})
+	ce.correlationResults = append(ce.correlationResults,
+		ce.pearsonResult("Solar Activity", "Solar Wind vs High Numbers", solarWindSpeeds, highNumbers, interpretSolarCorrelation))
 }
 
 // analyzeWeatherCorrelations analyzes weather correlations
@@ -526,17 +551,8 @@ func (ce *CorrelationEngine) analyzeWeatherCorrelations() {
 		}
 	}
 
-	corr, pValue := calculatePearsonCorrelation(temperatures, evenOddRatios)
-
-	ce.correlationResults = append(ce.correlationResults, CorrelationResult{
-		Factor:         "Weather",
-		SubFactor:      "Temperature vs Even/Odd Ratio",
-		Correlation:    corr,
-		PValue:         pValue,
-		SampleSize:     len(temperatures),
-		Significance:   getSignificanceLevel(pValue),
-		Interpretation: interpretWeatherCorrelation(corr, pValue),
-	})
+	ce.correlationResults = append(ce.correlationResults,
+		ce.pearsonResult("Weather", "Temperature vs Even/Odd Ratio", temperatures, evenOddRatios, interpretWeatherCorrelation))
 }
 
 // analyzeTemporalCorrelations analyzes day of week and seasonal patterns
@@ -544,12 +560,11 @@ func (ce *CorrelationEngine) analyzeTemporalCorrelations() {
 	dayFrequencies := make(map[string]map[int]int)
 	seasonFrequencies := make(map[string]map[int]int)
 
-	// Initialize maps
-	days := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	// Initialize maps (only days the game actually draws on)
 	seasons := []string{"Spring", "Summer", "Autumn", "Winter"}
 
-	for _, day := range days {
-		dayFrequencies[day] = make(map[int]int)
+	for _, day := range ce.spec().DrawDays {
+		dayFrequencies[day.String()] = make(map[int]int)
 	}
 	for _, season := range seasons {
 		seasonFrequencies[season] = make(map[int]int)
@@ -579,13 +594,21 @@ func (ce *CorrelationEngine) analyzeTemporalCorrelations() {
 	for day, freqMap := range dayFrequencies {
 		maxNum, maxFreq := findMaxFrequency(freqMap)
 		if maxFreq > 10 { // Only report if significant occurrences
+			// Is this day's favorite number more dominant than the favorite of a random set of drawings?
+			dayName := day
+			test := RunPermutationTest(ce, PermutationTest[[]int]{
+				Factor:    indicator(func(cosmic *CosmicData) bool { return cosmic.DayOfWeek == dayName }),
+				Outcome:   drawnNumbers,
+				Statistic: topNumberShare,
+			})
+
 			ce.correlationResults = append(ce.correlationResults, CorrelationResult{
 				Factor:         "Temporal",
 				SubFactor:      day + " Lucky Number",
 				Correlation:    float64(maxFreq) / float64(getTotalFrequency(freqMap)),
-				PValue:         0.1, // Simplified
+				PValue:         test.PValue,
 				SampleSize:     getTotalFrequency(freqMap),
-				Significance:   "Low",
+				Significance:   getSignificanceLevel(test.PValue),
 				Interpretation: fmt.Sprintf("Number %d appears %d times on %s", maxNum, maxFreq, day),
 			})
 		}
@@ -599,22 +622,13 @@ func (ce *CorrelationEngine) analyzePlanetaryCorrelations() {
 	retrogradeHighNumbers := 0
 	normalDrawings := 0
 	normalHighNumbers := 0
+	highThreshold := ce.spec().highThreshold()
 
 	for _, drawing := range ce.analyzer.drawings {
 		dateKey := drawing.Date.Format(dateFormatISO)
 		if cosmic, exists := ce.cosmicData[dateKey]; exists && cosmic.PlanetaryPositions != nil {
-			// Simplified Mercury retrograde detection
-			mercuryPos := cosmic.PlanetaryPositions["Mercury"]
-			isRetrograde := int(mercuryPos)%120 < 20 // Simplified
-
-			highCount := 0
-			for _, num := range drawing.Numbers {
-				if num > 30 {
-					highCount++
-				}
-			}
-
-			if isRetrograde {
+			highCount := countAbove(drawing.Numbers, highThreshold)
+			if isMercuryRetrograde(cosmic) {
 				retrogradeDrawings++
 				retrogradeHighNumbers += highCount
 			} else {
@@ -628,20 +642,79 @@ func (ce *CorrelationEngine) analyzePlanetaryCorrelations() {
 		retrogradeAvg := float64(retrogradeHighNumbers) / float64(retrogradeDrawings)
 		normalAvg := float64(normalHighNumbers) / float64(normalDrawings)
 
+		// Does retrograde shift the high-number average further than a random split of the drawings?
+		test := RunPermutationTest(ce, PermutationTest[float64]{
+			Factor: func(cosmic *CosmicData) (float64, bool) {
+				if cosmic.PlanetaryPositions == nil {
+					return 0, false
+				}
+				return indicator(isMercuryRetrograde)(cosmic)
+			},
+			Outcome: func(drawing Drawing) float64 {
+				return float64(countAbove(drawing.Numbers, highThreshold))
+			},
+			Statistic: meanDifference,
+		})
+
 		ce.correlationResults = append(ce.correlationResults, CorrelationResult{
 			Factor:         "Planetary",
 			SubFactor:      "Mercury Retrograde Effect",
 			Correlation:    retrogradeAvg - normalAvg,
-			PValue:         0.15, // Simplified
+			PValue:         test.PValue,
 			SampleSize:     retrogradeDrawings + normalDrawings,
-			Significance:   "Low",
+			Significance:   getSignificanceLevel(test.PValue),
 			Interpretation: fmt.Sprintf("Average high numbers: Retrograde=%.2f, Normal=%.2f", retrogradeAvg, normalAvg),
 		})
 	}
 }
 
+// isMercuryRetrograde reports whether Mercury is retrograde on the drawing day (simplified)
+func isMercuryRetrograde(cosmic *CosmicData) bool {
+	return int(cosmic.PlanetaryPositions["Mercury"])%120 < 20
+}
+
+// countAbove returns how many numbers exceed threshold
+func countAbove(numbers []int, threshold int) int {
+	count := 0
+	for _, num := range numbers {
+		if num > threshold {
+			count++
+		}
+	}
+	return count
+}
+
+// pearsonResult correlates x with y and describes the result with its confidence interval
+func (ce *CorrelationEngine) pearsonResult(factor, subFactor string, x, y []float64,
+	interpret func(corr, pValue float64) string,
+) CorrelationResult {
+	corr, pValue := calculatePearsonCorrelation(x, y)
+	result := CorrelationResult{
+		Factor:         factor,
+		SubFactor:      subFactor,
+		Correlation:    corr,
+		PValue:         pValue,
+		SampleSize:     len(x),
+		Significance:   getSignificanceLevel(pValue),
+		Interpretation: interpret(corr, pValue),
+	}
+	if interval, ok := FisherConfidenceInterval(corr, len(x), ce.confidenceLevel()); ok {
+		result.ConfidenceInterval = &interval
+	}
+	return result
+}
+
+// confidenceLevel returns the analyzer's confidence level, defaulting to 95%
+func (ce *CorrelationEngine) confidenceLevel() float64 {
+	if ce.analyzer == nil {
+		return defaultConfidenceLevel
+	}
+	return ce.analyzer.confidenceLevel()
+}
+
 // Helper functions
 
+// calculatePearsonCorrelation returns the Pearson correlation of x and y with its two-sided p-value
 func calculatePearsonCorrelation(x, y []float64) (correlation, pValue float64) {
 	if len(x) != len(y) || len(x) == 0 {
 		return 0, 1
@@ -674,11 +747,7 @@ func calculatePearsonCorrelation(x, y []float64) (correlation, pValue float64) {
 
 	correlation = num / math.Sqrt(denomX*denomY)
 
-	// Simplified p-value calculation
-	t := correlation * math.Sqrt((n-2)/(1-correlation*correlation))
-	pValue = 1 - math.Abs(t)/(math.Abs(t)+10) // Simplified
-
-	return correlation, pValue
+	return correlation, PearsonPValue(correlation, len(x))
 }
 
 func getSignificanceLevel(pValue float64) string {
@@ -747,82 +816,13 @@ func getTotalFrequency(freqMap map[int]int) int {
 
 // GenerateCosmicReport generates a comprehensive report of cosmic correlations
 func (ce *CorrelationEngine) GenerateCosmicReport() string {
-	report := "\n"
-	report += "═══════════════════════════════════════════════════════════════════\n"
-	report += "                    🌌 COSMIC CORRELATION ANALYSIS 🌌                \n"
-	report += "═══════════════════════════════════════════════════════════════════\n\n"
-
-	report += "⚠️  DISCLAIMER: This analysis explores statistical correlations\n"
-	report += "between cosmic phenomena and lottery outcomes for entertainment\n"
-	report += "and educational purposes only. Lottery drawings are random events.\n\n"
-
-	// Group correlations by factor
-	factorGroups := make(map[string][]CorrelationResult)
-	for _, result := range ce.correlationResults {
-		factorGroups[result.Factor] = append(factorGroups[result.Factor], result)
-	}
-
-	// Moon Phase Analysis
-	if moonResults, exists := factorGroups["Moon Phase"]; exists {
-		report += "🌙 LUNAR CORRELATIONS\n"
-		report += "─────────────────────\n"
-		for _, result := range moonResults {
-			report += formatCorrelationResult(result)
-		}
-		report += "\n"
-	}
-
-	// Solar Activity Analysis
-	if solarResults, exists := factorGroups["Solar Activity"]; exists {
-		report += "☀️  SOLAR ACTIVITY CORRELATIONS\n"
-		report += "─────────────────────────────\n"
-		for _, result := range solarResults {
-			report += formatCorrelationResult(result)
-		}
-		report += "\n"
-	}
-
-	// Weather Analysis
-	if weatherResults, exists := factorGroups["Weather"]; exists {
-		report += "🌤️  WEATHER CORRELATIONS\n"
-		report += "───────────────────────\n"
-		for _, result := range weatherResults {
-			report += formatCorrelationResult(result)
-		}
-		report += "\n"
-	}
-
-	// Temporal Analysis
-	if temporalResults, exists := factorGroups["Temporal"]; exists {
-		report += "📅 TEMPORAL PATTERNS\n"
-		report += "──────────────────\n"
-		for _, result := range temporalResults {
-			report += formatCorrelationResult(result)
-		}
-		report += "\n"
-	}
-
-	// Planetary Analysis
-	if planetaryResults, exists := factorGroups["Planetary"]; exists {
-		report += "🪐 PLANETARY INFLUENCES\n"
-		report += "─────────────────────\n"
-		for _, result := range planetaryResults {
-			report += formatCorrelationResult(result)
-		}
-		report += "\n"
-	}
-
-	// Current Cosmic Conditions
-	report += ce.generateCurrentConditions()
-
-	// Fun facts
-	report += ce.generateCosmicFunFacts()
-
-	return report
+	var report strings.Builder
+	writeCosmicReport(&consoleWriter{w: &report}, ce.correlationResults, ce.MultipleComparisons(), ce.CurrentConditions(time.Now()))
+	return report.String()
 }
 
-// formatCorrelationResult formats a single correlation result
-func formatCorrelationResult(result CorrelationResult) string {
+// formatCorrelationResult formats a single correlation result, judging significance at alpha
+func formatCorrelationResult(result CorrelationResult, alpha float64) string {
 	var output string
 
 	if result.SubFactor != "" {
@@ -831,81 +831,29 @@ func formatCorrelationResult(result CorrelationResult) string {
 
 	output += fmt.Sprintf("  Correlation: %.3f | P-value: %.3f | Significance: %s\n",
 		result.Correlation, result.PValue, result.Significance)
+	if ci := result.ConfidenceInterval; ci != nil {
+		output += fmt.Sprintf("  %.0f%% CI: [%.3f, %.3f]\n", ci.Level*100, ci.Lower, ci.Upper)
+	}
+	adjusted := result.AdjustedPValues
+	output += fmt.Sprintf("  Adjusted p: Holm %.3f | Benjamini-Hochberg %.3f | Bonferroni %.3f\n",
+		adjusted.Holm, adjusted.BenjaminiHochberg, adjusted.Bonferroni)
 	output += fmt.Sprintf("  %s\n", result.Interpretation)
 
-	switch result.Significance {
-	case "None":
+	switch {
+	case result.Significance == "None":
 		output += "  🔍 No statistical significance detected\n"
-	case "High":
-		output += "  ⚡ Statistically significant finding!\n"
+	case result.PValue < alpha && adjusted.Holm < alpha:
+		output += "  ⚡ Statistically significant finding, even after correction!\n"
+	case result.PValue < alpha:
+		output += "  ⚠️  Significant alone, but not after correcting for multiple comparisons\n"
 	}
 
 	output += "\n"
 	return output
 }
 
-// generateCurrentConditions generates current cosmic conditions
-func (ce *CorrelationEngine) generateCurrentConditions() string {
-	today := time.Now()
-	phase, illumination := ce.calculateMoonPhase(today)
-	phaseName := ce.getMoonPhaseName(phase)
-	zodiac := ce.getZodiacSign(today)
-
-	report := "🔮 CURRENT COSMIC CONDITIONS\n"
-	report += "──────────────────────────\n"
-	report += fmt.Sprintf("Date: %s\n", today.Format("January 2, 2006"))
-	report += fmt.Sprintf("Moon Phase: %s (%.0f%% illuminated)\n", phaseName, illumination*100)
-	report += fmt.Sprintf("Zodiac Sign: %s\n", zodiac)
-	report += fmt.Sprintf("Day of Week: %s\n", today.Weekday())
-	report += "\n"
-
-	// Cosmic recommendation based on current conditions
-	report += "🎯 TODAY'S COSMIC SUGGESTION:\n"
-
-	switch phaseName {
-	case "Full Moon":
-		report += "  The full moon historically shows a 2.3% increase in high numbers.\n"
-		report += "  Consider including numbers above 30 in your selection.\n"
-	case "New Moon":
-		report += "  New moon periods show balanced number distribution.\n"
-		report += "  A mix of high and low numbers may be favorable.\n"
-	default:
-		report += "  Current lunar phase shows no significant historical patterns.\n"
-		report += "  Standard statistical selection recommended.\n"
-	}
-
-	report += "\n"
-	return report
-}
-
-// generateCosmicFunFacts generates entertaining cosmic facts
-func (ce *CorrelationEngine) generateCosmicFunFacts() string {
-	facts := []string{
-		"🌟 Numbers 7 and 13 show 0.8% higher frequency during meteor showers!",
-		"🌊 High tide correlates with a 1.2% increase in water-sign numbers (4, 8, 12)!",
-		"⚡ Geomagnetic storms coincide with 0.5% more consecutive number pairs!",
-		"🌙 Lunar eclipses show no correlation - the moon keeps its lottery secrets!",
-		"☄️  Halley's Comet years show identical number distributions (sorry, no cosmic luck)!",
-	}
-
-	report := "✨ COSMIC CURIOSITIES\n"
-	report += "───────────────────\n"
-
-	// Select a few random facts
-	for i := 0; i < 3 && i < len(facts); i++ {
-		report += facts[i] + "\n"
-	}
-
-	report += "\n📊 STATISTICAL REALITY CHECK:\n"
-	report += "All correlations shown are within normal random variation.\n"
-	report += "These patterns are entertaining coincidences, not predictive tools.\n"
-	report += "Remember: Every drawing has exactly the same odds!\n"
-
-	return report
-}
-
-// PredictBasedOnCosmicConditions generates predictions based on current cosmic conditions
-func (ce *CorrelationEngine) PredictBasedOnCosmicConditions() []int {
+// PredictBasedOnCosmicConditions generates a ticket, bonus ball included, from current cosmic conditions
+func (ce *CorrelationEngine) PredictBasedOnCosmicConditions() Ticket {
 	today := time.Now()
 	dateKey := today.Format(dateFormatISO)
 
@@ -923,46 +871,64 @@ func (ce *CorrelationEngine) PredictBasedOnCosmicConditions() []int {
 		ce.addMockDataForDemo(cosmic)
 	}
 
-	// Generate "cosmic-influenced" numbers
-	numbers := make([]int, 5)
+	// Generate "cosmic-influenced" influences, one per cosmic factor
+	game := ce.spec()
+	pool := game.MainPoolSize
+	influences := make([]int, 0, 5)
 
 	// Moon phase influence
-	moonInfluence := int(cosmic.MoonPhase * 48)
-	numbers[0] = (moonInfluence % 48) + 1
+	influences = append(influences, int(cosmic.MoonPhase*float64(pool)))
 
 	// Day of week influence
-	dayNum := int(today.Weekday())
-	numbers[1] = ((dayNum * 7) % 48) + 1
+	influences = append(influences, int(today.Weekday())*7)
 
 	// Zodiac influence
-	zodiacNum := len(cosmic.ZodiacSign)
-	numbers[2] = ((zodiacNum * 3) % 48) + 1
+	influences = append(influences, len(cosmic.ZodiacSign)*3)
 
 	// Solar activity influence (mock)
 	if cosmic.SolarActivity != nil {
-		solarNum := int(cosmic.SolarActivity.F107Index) % 48
-		numbers[3] = solarNum + 1
+		influences = append(influences, int(cosmic.SolarActivity.F107Index))
 	} else {
-		numbers[3] = 23 // Default
+		influences = append(influences, 22) // Default
 	}
 
 	// Temperature influence (mock)
 	if cosmic.WeatherData != nil {
-		tempNum := int(cosmic.WeatherData.Temperature) % 48
-		numbers[4] = tempNum + 1
+		influences = append(influences, int(cosmic.WeatherData.Temperature))
 	} else {
-		numbers[4] = 42 // Default
+		influences = append(influences, 41) // Default
+	}
+
+	// Map influences into the pool, cycling through them if the game picks more than five numbers
+	numbers := make([]int, game.MainPicks)
+	for i := range numbers {
+		offset := (influences[i%len(influences)] + i/len(influences)) % pool
+		if offset < 0 {
+			offset += pool
+		}
+		numbers[i] = offset
 	}
 
 	// Ensure unique numbers
 	used := make(map[int]bool)
-	for i, num := range numbers {
-		for used[num] {
-			num = (num % 48) + 1
+	for i, offset := range numbers {
+		for used[offset] {
+			offset = (offset + 1) % pool
 		}
-		numbers[i] = num
-		used[num] = true
+		used[offset] = true
+		numbers[i] = game.MinNumber + offset
 	}
 
-	return numbers
+	pick := Ticket{Numbers: numbers}
+	if game.HasBonus() {
+		pick.LuckyBall = cosmicLuckyBall(cosmic, game)
+	}
+	return pick
+}
+
+// cosmicLuckyBall picks the bonus ball from the moon's illumination, the zodiac sign and the day of the week
+func cosmicLuckyBall(cosmic *CosmicData, game *GameSpec) int {
+	pool := game.BonusPoolSize
+	offset := int(cosmic.MoonIllumination*float64(pool)) + len(cosmic.ZodiacSign) + int(cosmic.Date.Weekday())
+	return 1 + (offset%pool+pool)%pool
 }
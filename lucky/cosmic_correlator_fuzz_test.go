@@ -1,6 +1,6 @@
 //go:build go1.18
 
-package main
+package lucky
 
 import (
 	"context"
@@ -491,7 +491,7 @@ func FuzzPredictBasedOnCosmicConditions(f *testing.F) {
 		ce.cosmicData[dateKey] = cosmic
 
 		// Should not panic
-		numbers := ce.PredictBasedOnCosmicConditions()
+		numbers := ce.PredictBasedOnCosmicConditions().Numbers
 
 		// Validate predictions
 		if len(numbers) != 5 {
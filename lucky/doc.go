@@ -0,0 +1,25 @@
+// Package lucky provides the lottery analysis engine behind the go-lucky CLI.
+//
+// An Analyzer parses a drawing history CSV for a game (see GameSpec), then tracks number
+// frequencies, gaps, combination patterns and chi-square randomness statistics. It can
+// generate RecommendedSet picks using several strategies, and its CorrelationEngine runs
+// the (entirely tongue-in-cheek) cosmic correlation analysis against moon phases, solar
+// activity, weather and planetary positions.
+//
+// Basic usage:
+//
+//	analyzer, err := lucky.NewAnalyzer(ctx, "lucky-numbers-history.csv", &lucky.AnalysisConfig{
+//		Game: lucky.GameLuckyForLife,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	hot := analyzer.GetTopNumbers(10, true)
+//	sets, err := analyzer.GenerateRecommendations(ctx, 5)
+//
+// Results returned by the accessor methods are copies, so callers may keep or modify them
+// without affecting the analyzer.
+//
+// Remember: Past performance does not predict future results, and the stars are not
+// responsible for your gambling decisions!
+package lucky
@@ -0,0 +1,265 @@
+package lucky
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidBankroll indicates a bankroll simulation that cannot be run
+var ErrInvalidBankroll = errors.New("invalid bankroll simulation")
+
+const (
+	// weeksPerYear is the number of weeks a simulated year of play covers
+	weeksPerYear = 52
+
+	// MaxBankrollTicketsPerWeek caps the tickets bought each simulated week
+	MaxBankrollTicketsPerWeek = 10_000
+
+	// MaxBankrollYears caps the length of a simulated bankroll
+	MaxBankrollYears = 100
+)
+
+// TicketValue is what one ticket returns on average, from the exact odds of every prize tier.
+// It is the same for every ticket of a game, whichever numbers or strategy picked it.
+type TicketValue struct {
+	TicketPrice         float64 `json:"ticket_price"`
+	ExpectedReturn      float64 `json:"expected_return"`       // Average cash won per ticket
+	ROI                 float64 `json:"roi"`                   // Long-run return on the price: (expected return - price) / price
+	AnyPrizeProbability float64 `json:"any_prize_probability"` // Chance of winning any prize tier
+}
+
+// TierOdds is the chance of winning one prize tier and what it adds to the expected return
+type TierOdds struct {
+	Name         string  `json:"name"`
+	Prize        string  `json:"prize"`
+	CashValue    float64 `json:"cash_value"`
+	Probability  float64 `json:"probability"`
+	Odds         float64 `json:"odds"`         // One win in Odds tickets
+	Contribution float64 `json:"contribution"` // Probability times cash value
+}
+
+// ExpectedValueReport is the expected value of a game's ticket, tier by tier
+type ExpectedValueReport struct {
+	Game  string      `json:"game"`
+	Value TicketValue `json:"value"`
+	Tiers []TierOdds  `json:"tiers"`
+}
+
+// TicketValue returns the expected return, ROI and chance of any prize of one ticket of game
+func (p *PrizeTable) TicketValue(game *GameSpec) TicketValue {
+	value := TicketValue{TicketPrice: p.TicketPrice, ExpectedReturn: p.ExpectedValue(game)}
+	for _, tier := range p.Tiers {
+		value.AnyPrizeProbability += tier.Probability(game)
+	}
+	if p.TicketPrice > 0 {
+		value.ROI = (value.ExpectedReturn - p.TicketPrice) / p.TicketPrice
+	}
+	return value
+}
+
+// String describes the value, e.g. "Expected return $0.65 per $2.00 ticket (ROI -67.3%), any prize 1 in 7.76"
+func (v TicketValue) String() string {
+	return fmt.Sprintf("Expected return $%.2f per $%.2f ticket (ROI %.1f%%), any prize 1 in %s",
+		v.ExpectedReturn, v.TicketPrice, v.ROI*100, formatOdds(1/v.AnyPrizeProbability))
+}
+
+// ExpectedValueReport returns the odds and expected value of every prize tier of game
+func (p *PrizeTable) ExpectedValueReport(game *GameSpec) *ExpectedValueReport {
+	report := &ExpectedValueReport{Game: game.Key, Value: p.TicketValue(game), Tiers: make([]TierOdds, len(p.Tiers))}
+	for i, tier := range p.Tiers {
+		probability := tier.Probability(game)
+		report.Tiers[i] = TierOdds{
+			Name:         tier.Name,
+			Prize:        tier.Prize,
+			CashValue:    tier.CashValue,
+			Probability:  probability,
+			Odds:         1 / probability,
+			Contribution: probability * tier.CashValue,
+		}
+	}
+	return report
+}
+
+// ticketValue returns the value of one ticket of the analyzed game, or nil when its prizes are not known
+func (a *Analyzer) ticketValue() *TicketValue {
+	prizes, err := a.PrizeTable()
+	if err != nil {
+		return nil
+	}
+	value := prizes.TicketValue(a.spec())
+	return &value
+}
+
+// BankrollConfig describes the play to simulate
+type BankrollConfig struct {
+	TicketsPerWeek int   `json:"tickets_per_week"`
+	Years          int   `json:"years"`
+	Seed           int64 `json:"seed"` // Seed of the simulated drawings; the same seed repeats the run
+}
+
+// BankrollPoint is the simulated bankroll at the end of a week
+type BankrollPoint struct {
+	Week            int     `json:"week"`
+	Spent           float64 `json:"spent"`
+	Won             float64 `json:"won"`
+	Balance         float64 `json:"balance"`          // Won minus spent so far
+	ExpectedBalance float64 `json:"expected_balance"` // Balance had every ticket returned exactly the expected return
+}
+
+// BankrollSimulation is one simulated run of buying tickets every week
+type BankrollSimulation struct {
+	Game            string          `json:"game"`
+	Config          BankrollConfig  `json:"config"`
+	Tickets         int             `json:"tickets"`
+	Spent           float64         `json:"spent"`
+	Won             float64         `json:"won"`
+	Balance         float64         `json:"balance"`
+	ROI             float64         `json:"roi"`              // Realized return on the money spent
+	ExpectedBalance float64         `json:"expected_balance"` // Balance at the expected return
+	TopPrizeChance  float64         `json:"top_prize_chance"` // Chance of winning the top tier at least once in this many tickets
+	Wins            map[string]int  `json:"wins,omitempty"`   // Wins by prize tier name
+	Curve           []BankrollPoint `json:"curve,omitempty"`  // Bankroll at the end of every week
+}
+
+// SimulateBankroll plays TicketsPerWeek tickets a week for Years years, drawing each ticket's prize tier
+// at random with its exact probability. Lifetime prizes count at their lump-sum cash value.
+func (p *PrizeTable) SimulateBankroll(game *GameSpec, config BankrollConfig) (*BankrollSimulation, error) {
+	if config.TicketsPerWeek < 1 || config.TicketsPerWeek > MaxBankrollTicketsPerWeek {
+		return nil, fmt.Errorf("%w: tickets per week must be between 1 and %d, got %d",
+			ErrInvalidBankroll, MaxBankrollTicketsPerWeek, config.TicketsPerWeek)
+	}
+	if config.Years < 1 || config.Years > MaxBankrollYears {
+		return nil, fmt.Errorf("%w: years must be between 1 and %d, got %d", ErrInvalidBankroll, MaxBankrollYears, config.Years)
+	}
+
+	// A uniform draw below cumulative[i] and at or above cumulative[i-1] wins tier i
+	cumulative := make([]float64, len(p.Tiers))
+	total := 0.0
+	for i, tier := range p.Tiers {
+		total += tier.Probability(game)
+		cumulative[i] = total
+	}
+
+	value := p.TicketValue(game)
+	weeks := config.Years * weeksPerYear
+	sim := &BankrollSimulation{Game: game.Key, Config: config, Curve: make([]BankrollPoint, 0, weeks)}
+	rng := rand.New(rand.NewPCG(uint64(config.Seed), uint64(config.Seed))) //nolint:gosec // a repeatable simulation needs a seeded generator, not a secure one
+	for week := 1; week <= weeks; week++ {
+		for range config.TicketsPerWeek {
+			sim.Tickets++
+			sim.Spent += p.TicketPrice
+			draw := rng.Float64()
+			if tier := sort.Search(len(cumulative), func(i int) bool { return draw < cumulative[i] }); tier < len(p.Tiers) {
+				if sim.Wins == nil {
+					sim.Wins = make(map[string]int)
+				}
+				sim.Wins[p.Tiers[tier].Name]++
+				sim.Won += p.Tiers[tier].CashValue
+			}
+		}
+		sim.Curve = append(sim.Curve, BankrollPoint{
+			Week:            week,
+			Spent:           sim.Spent,
+			Won:             sim.Won,
+			Balance:         sim.Won - sim.Spent,
+			ExpectedBalance: float64(sim.Tickets) * (value.ExpectedReturn - p.TicketPrice),
+		})
+	}
+
+	sim.Balance = sim.Won - sim.Spent
+	if sim.Spent > 0 {
+		sim.ROI = sim.Balance / sim.Spent
+	}
+	sim.ExpectedBalance = float64(sim.Tickets) * (value.ExpectedReturn - p.TicketPrice)
+	if len(p.Tiers) > 0 {
+		sim.TopPrizeChance = -math.Expm1(float64(sim.Tickets) * math.Log1p(-p.Tiers[0].Probability(game)))
+	}
+	return sim, nil
+}
+
+// ValueReport is the expected value of a game's ticket together with a simulated bankroll
+type ValueReport struct {
+	ExpectedValue *ExpectedValueReport `json:"expected_value"`
+	Bankroll      *BankrollSimulation  `json:"bankroll"`
+}
+
+// Records flattens the report into NDJSON records: the expected value, the bankroll summary, then one
+// record per simulated week
+func (r *ValueReport) Records() []Record {
+	summary := *r.Bankroll
+	summary.Curve = nil
+	records := make([]Record, 0, len(r.Bankroll.Curve)+2)
+	records = append(records,
+		Record{Type: RecordTypeExpectedValue, Data: r.ExpectedValue},
+		Record{Type: RecordTypeBankrollSummary, Data: summary})
+	for _, point := range r.Bankroll.Curve {
+		records = append(records, Record{Type: RecordTypeBankrollPoint, Data: point})
+	}
+	return records
+}
+
+// WriteValueReport writes the tier odds, expected return and the simulated bankroll year by year
+func WriteValueReport(w io.Writer, game GameSpec, report *ValueReport) error {
+	c := &consoleWriter{w: w}
+	ev, sim := report.ExpectedValue, report.Bankroll
+	c.section("💵 EXPECTED VALUE")
+	c.printf("Game: %s ($%.2f ticket)\n\n", game.Name, ev.Value.TicketPrice)
+	c.printf("%-6s %-24s %14s %14s\n", "Tier", "Prize", "Odds (1 in)", "Expected $")
+	for _, tier := range ev.Tiers {
+		c.printf("%-6s %-24s %14s %14.4f\n", tier.Name, tier.Prize, formatOdds(tier.Odds), tier.Contribution)
+	}
+	c.printf("%-31s %14s %14.4f\n\n", "Any prize", formatOdds(1/ev.Value.AnyPrizeProbability), ev.Value.ExpectedReturn)
+	c.printf("Expected return per $%.2f ticket: $%.4f\n", ev.Value.TicketPrice, ev.Value.ExpectedReturn)
+	c.printf("Chance of any prize: %.2f%%\n", ev.Value.AnyPrizeProbability*100)
+	c.printf("Long-run ROI: %.1f%% (about $%.2f back for every $1 played)\n",
+		ev.Value.ROI*100, 1+ev.Value.ROI)
+	c.println("Every ticket has these odds, whichever numbers or strategy picked it.")
+
+	c.section("🏦 SIMULATED BANKROLL")
+	c.printf("%d ticket(s) a week for %d year(s), %d tickets (seed %d)\n\n",
+		sim.Config.TicketsPerWeek, sim.Config.Years, sim.Tickets, sim.Config.Seed)
+	c.printf("%-5s %14s %14s %14s %14s\n", "Year", "Spent", "Won", "Balance", "Expected")
+	for _, point := range sim.Curve {
+		if point.Week%weeksPerYear == 0 {
+			c.printf("%-5d %14.2f %14.2f %14.2f %14.2f\n",
+				point.Week/weeksPerYear, point.Spent, point.Won, point.Balance, point.ExpectedBalance)
+		}
+	}
+	c.printf("\nRealized ROI: %.1f%% (expected %.1f%%)\n", sim.ROI*100, ev.Value.ROI*100)
+	names := make([]string, 0, len(sim.Wins))
+	for _, tier := range ev.Tiers {
+		if sim.Wins[tier.Name] > 0 {
+			names = append(names, fmt.Sprintf("%s ×%d", tier.Name, sim.Wins[tier.Name]))
+		}
+	}
+	if len(names) > 0 {
+		c.printf("Wins: %s\n", strings.Join(names, ", "))
+	}
+	c.printf("Chance of the top prize at least once in %d tickets: %.4f%%\n", sim.Tickets, sim.TopPrizeChance*100)
+	c.println("Lifetime prizes count at their lump-sum cash value; taxes are ignored.")
+	return c.err
+}
+
+// formatOdds formats "1 in n" odds with thousands separators, keeping a decimal for short odds
+func formatOdds(odds float64) string {
+	if math.IsInf(odds, 0) || math.IsNaN(odds) {
+		return "-"
+	}
+	if odds < 100 {
+		return fmt.Sprintf("%.2f", odds)
+	}
+	digits := fmt.Sprintf("%.0f", odds)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return grouped.String()
+}
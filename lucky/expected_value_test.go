@@ -0,0 +1,111 @@
+package lucky
+
+import (
+	"bytes"
+	"context"
+	"math"
+)
+
+// TestTicketValue tests the expected return, ROI and odds of any prize against the published figures
+func (s *AnalyzerTestSuite) TestTicketValue() {
+	prizes := luckyForLifePrizes()
+	game := luckyForLifeSpec()
+
+	value := prizes.TicketValue(game)
+	s.InDelta(prizes.ExpectedValue(game), value.ExpectedReturn, 0)
+	s.InDelta(value.ExpectedReturn/2-1, value.ROI, 1e-12)
+	s.Negative(value.ROI)
+	s.InDelta(7.77, 1/value.AnyPrizeProbability, 0.005) // Published overall odds: 1 in 7.8
+	s.Equal("Expected return $1.15 per $2.00 ticket (ROI -42.3%), any prize 1 in 7.77", value.String())
+
+	report := prizes.ExpectedValueReport(game)
+	s.Equal(GameLuckyForLife, report.Game)
+	s.Require().Len(report.Tiers, len(prizes.Tiers))
+	total, probability := 0.0, 0.0
+	for _, tier := range report.Tiers {
+		total += tier.Contribution
+		probability += tier.Probability
+		s.InDelta(1, tier.Odds*tier.Probability, 1e-12, tier.Name)
+	}
+	s.InDelta(value.ExpectedReturn, total, 1e-12)
+	s.InDelta(value.AnyPrizeProbability, probability, 1e-12)
+	s.Equal("30,821,472", formatOdds(report.Tiers[0].Odds))
+
+	// Every recommended set carries the same value, whichever strategy picked it
+	sets, err := s.analyzer.GenerateRecommendations(context.Background(), 3)
+	s.Require().NoError(err)
+	for _, set := range sets {
+		s.Require().NotNil(set.Value)
+		s.Equal(value, *set.Value)
+	}
+}
+
+// TestSimulateBankroll tests that simulated play is repeatable and wins prizes at their exact rates
+func (s *AnalyzerTestSuite) TestSimulateBankroll() {
+	prizes := luckyForLifePrizes()
+	game := luckyForLifeSpec()
+	value := prizes.TicketValue(game)
+
+	config := BankrollConfig{TicketsPerWeek: 100, Years: 10, Seed: 42}
+	sim, err := prizes.SimulateBankroll(game, config)
+	s.Require().NoError(err)
+	s.Equal(52_000, sim.Tickets)
+	s.InDelta(104_000, sim.Spent, 1e-6)
+	s.InDelta(sim.Won-sim.Spent, sim.Balance, 1e-6)
+	s.InDelta(sim.Balance/sim.Spent, sim.ROI, 1e-12)
+	s.InDelta(52_000*(value.ExpectedReturn-2), sim.ExpectedBalance, 1e-6)
+	s.InDelta(-math.Expm1(52_000*math.Log1p(-1/30_821_472.0)), sim.TopPrizeChance, 1e-9)
+
+	s.Require().Len(sim.Curve, 520)
+	last := sim.Curve[len(sim.Curve)-1]
+	s.Equal(520, last.Week)
+	s.InDelta(sim.Balance, last.Balance, 1e-6)
+	s.InDelta(sim.ExpectedBalance, last.ExpectedBalance, 1e-6)
+
+	// Wins total the winnings, at about the chance of any prize (a spread of 5 standard deviations)
+	wins, won := 0, 0.0
+	for name, count := range sim.Wins {
+		wins += count
+		won += float64(count) * prizes.Tier(tierMatches(name)).CashValue
+	}
+	s.InDelta(sim.Won, won, 1e-6)
+	expected := 52_000 * value.AnyPrizeProbability
+	s.InDelta(expected, float64(wins), 5*math.Sqrt(expected*(1-value.AnyPrizeProbability)))
+
+	repeat, err := prizes.SimulateBankroll(game, config)
+	s.Require().NoError(err)
+	s.Equal(sim, repeat)
+
+	for _, config := range []BankrollConfig{
+		{TicketsPerWeek: 0, Years: 1},
+		{TicketsPerWeek: MaxBankrollTicketsPerWeek + 1, Years: 1},
+		{TicketsPerWeek: 1, Years: 0},
+		{TicketsPerWeek: 1, Years: MaxBankrollYears + 1},
+	} {
+		_, err = prizes.SimulateBankroll(game, config)
+		s.Require().ErrorIs(err, ErrInvalidBankroll, "Config %+v", config)
+	}
+}
+
+// TestValueReport tests the records and console output of the expected value report
+func (s *AnalyzerTestSuite) TestValueReport() {
+	prizes := luckyForLifePrizes()
+	game := luckyForLifeSpec()
+	sim, err := prizes.SimulateBankroll(game, BankrollConfig{TicketsPerWeek: 2, Years: 2, Seed: 1})
+	s.Require().NoError(err)
+	report := &ValueReport{ExpectedValue: prizes.ExpectedValueReport(game), Bankroll: sim}
+
+	records := report.Records()
+	s.Require().Len(records, 2+104)
+	s.Equal(RecordTypeExpectedValue, records[0].Type)
+	s.Equal(RecordTypeBankrollSummary, records[1].Type)
+	s.Nil(records[1].Data.(BankrollSimulation).Curve)
+	s.Equal(RecordTypeBankrollPoint, records[2].Type)
+	s.Len(sim.Curve, 104) // The summary leaves the report's curve alone
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteValueReport(&buf, *game, report))
+	s.Contains(buf.String(), "30,821,472")
+	s.Contains(buf.String(), "Long-run ROI: -42.3%")
+	s.Contains(buf.String(), "2 ticket(s) a week for 2 year(s), 208 tickets (seed 1)")
+}
@@ -0,0 +1,158 @@
+package lucky
+
+import (
+	"context"
+	_ "embed" // Embeds the export JSON Schema
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ErrUnsupportedSchemaVersion indicates an export written with an incompatible schema
+var ErrUnsupportedSchemaVersion = errors.New("unsupported export schema version")
+
+// ErrInvalidExport indicates an export document that is missing required sections
+var ErrInvalidExport = errors.New("invalid export document")
+
+// ExportSchemaVersion is the schema_version written to JSON exports.
+// It changes whenever a field is renamed or removed.
+const ExportSchemaVersion = "2"
+
+// exportSchema is the JSON Schema describing ExportDocument
+//
+//go:embed schema/export.schema.json
+var exportSchema []byte
+
+// ExportDocument is the complete analysis as written by the JSON export
+type ExportDocument struct {
+	SchemaVersion  string               `json:"schema_version"`
+	Config         AnalysisConfig       `json:"config"`
+	Report         *Report              `json:"report"`
+	MainNumbers    []NumberInfo         `json:"main_numbers"` // Ordered by number
+	LuckyBalls     []NumberInfo         `json:"lucky_balls"`  // Ordered by number
+	Patterns       PatternStats         `json:"patterns"`
+	PairPatterns   []CombinationPattern `json:"pair_patterns"` // Most frequent first
+	PairTests      []PairSignificance   `json:"pair_tests"`    // Every pair, most significant first
+	TriplePatterns []CombinationPattern `json:"triple_patterns"`
+	QuadPatterns   []CombinationPattern `json:"quad_patterns"`
+	Drawings       []ExportDrawing      `json:"drawings"` // Most recent first
+}
+
+// ExportDrawing is a drawing with the cosmic data recorded for its date
+type ExportDrawing struct {
+	Drawing
+	Cosmic *CosmicData `json:"cosmic,omitempty"`
+}
+
+// ExportSchema returns the JSON Schema of the export document
+func ExportSchema() []byte {
+	return append([]byte(nil), exportSchema...)
+}
+
+// BuildExport runs the cosmic correlation analysis and snapshots the full analysis
+func (a *Analyzer) BuildExport(ctx context.Context) (*ExportDocument, error) {
+	a.runCosmicAnalysis(ctx)
+
+	report, err := a.BuildReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ExportDocument{
+		SchemaVersion:  ExportSchemaVersion,
+		Config:         a.Config(),
+		Report:         report,
+		MainNumbers:    sortedNumberInfos(a.mainNumbers),
+		LuckyBalls:     sortedNumberInfos(a.luckyBalls),
+		Patterns:       a.PatternStats(),
+		PairPatterns:   topPatterns(a.pairPatterns, len(a.pairPatterns)),
+		PairTests:      a.PairTests(),
+		TriplePatterns: topPatterns(a.triplePatterns, len(a.triplePatterns)),
+		QuadPatterns:   topPatterns(a.quadPatterns, len(a.quadPatterns)),
+		Drawings:       make([]ExportDrawing, len(a.drawings)),
+	}
+	for i, drawing := range a.Drawings() {
+		doc.Drawings[i].Drawing = drawing
+		if a.correlationEngine == nil {
+			continue
+		}
+		if cosmic, ok := a.correlationEngine.CosmicData(drawing.Date); ok {
+			doc.Drawings[i].Cosmic = &cosmic
+		}
+	}
+	return doc, nil
+}
+
+// exportJSON exports the full analysis as a versioned JSON document
+func (a *Analyzer) exportJSON(ctx context.Context, filename string) error {
+	if err := validateFilePath(filename); err != nil {
+		return fmt.Errorf(errMsgInvalidFilePath, err)
+	}
+
+	file, err := os.Create(filename) // #nosec G304 - path validated above
+	if err != nil {
+		return fmt.Errorf(errMsgFailedToCreateFile, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			// Log error but don't return it as we're in defer
+			_, _ = fmt.Fprintf(a.progressWriter(), errMsgFailedToCloseFile, closeErr)
+		}
+	}()
+
+	doc, err := a.BuildExport(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// LoadExport reads a JSON export written by ExportAnalysis
+func LoadExport(filename string) (*ExportDocument, error) {
+	if err := validateFilePath(filename); err != nil {
+		return nil, fmt.Errorf(errMsgInvalidFilePath, err)
+	}
+
+	file, err := os.Open(filename) // #nosec G304 - path validated above
+	if err != nil {
+		return nil, fmt.Errorf(errMsgFailedToOpenFile, err)
+	}
+	defer func() {
+		_ = file.Close() // Read-only file, close errors are not actionable
+	}()
+
+	return ReadExport(file)
+}
+
+// ReadExport decodes a JSON export and checks its schema version
+func ReadExport(r io.Reader) (*ExportDocument, error) {
+	var doc ExportDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if doc.SchemaVersion != ExportSchemaVersion {
+		return nil, fmt.Errorf("%w: got %q, want %q", ErrUnsupportedSchemaVersion, doc.SchemaVersion, ExportSchemaVersion)
+	}
+	if doc.Report == nil {
+		return nil, fmt.Errorf("%w: missing report", ErrInvalidExport)
+	}
+	return &doc, nil
+}
+
+// sortedNumberInfos copies number statistics into a slice ordered by number
+func sortedNumberInfos(numbers map[int]*NumberInfo) []NumberInfo {
+	infos := make([]NumberInfo, 0, len(numbers))
+	for _, info := range copyNumberInfos(numbers) {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Number < infos[j].Number
+	})
+	return infos
+}
@@ -0,0 +1,479 @@
+package lucky
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// BundleManifestFile is the name of the manifest inside a CSV bundle
+	BundleManifestFile = "manifest.json"
+
+	// bundleDirPerm is the permission of a CSV bundle directory
+	bundleDirPerm = 0o750
+)
+
+// CSV bundle column types
+const (
+	ColumnTypeInteger = "integer"
+	ColumnTypeNumber  = "number"
+	ColumnTypeString  = "string"
+	ColumnTypeDate    = "date" // YYYY-MM-DD
+)
+
+// BundleManifest describes the tables of a CSV export bundle
+type BundleManifest struct {
+	SchemaVersion string        `json:"schema_version"`
+	Game          string        `json:"game"`
+	GeneratedAt   time.Time     `json:"generated_at"`
+	Tables        []BundleTable `json:"tables"`
+}
+
+// BundleTable describes one CSV file of a bundle
+type BundleTable struct {
+	Name        string         `json:"name"`
+	File        string         `json:"file"`
+	Description string         `json:"description"`
+	Rows        int            `json:"rows"`
+	Columns     []BundleColumn `json:"columns"`
+}
+
+// BundleColumn describes one CSV column and how to parse it
+type BundleColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// csvTable is a table ready to be written as CSV
+type csvTable struct {
+	BundleTable
+	rows [][]string
+}
+
+// column appends a column definition to the table
+func (t *csvTable) column(name, kind string) {
+	t.Columns = append(t.Columns, BundleColumn{Name: name, Type: kind})
+}
+
+// header returns the column names
+func (t *csvTable) header() []string {
+	names := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// exportCSV exports the analysis as a bundle of CSV tables with a manifest.
+// A filename ending in .zip produces an archive; anything else is a directory.
+func (a *Analyzer) exportCSV(ctx context.Context, filename string) error {
+	if err := validateFilePath(filename); err != nil {
+		return fmt.Errorf(errMsgInvalidFilePath, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(filename), ".zip") {
+		return a.exportCSVZip(ctx, filename)
+	}
+	return a.exportCSVDir(ctx, filename)
+}
+
+// exportCSVDir writes the CSV bundle into a directory
+func (a *Analyzer) exportCSVDir(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, bundleDirPerm); err != nil {
+		return fmt.Errorf(errMsgFailedToCreateFile, err)
+	}
+
+	return a.writeCSVBundle(ctx, func(name string, write func(io.Writer) error) error {
+		file, err := os.Create(filepath.Join(dir, name)) // #nosec G304 - directory validated above
+		if err != nil {
+			return fmt.Errorf(errMsgFailedToCreateFile, err)
+		}
+		if err = write(file); err != nil {
+			_ = file.Close() // The write error is more useful
+			return err
+		}
+		return file.Close()
+	})
+}
+
+// exportCSVZip writes the CSV bundle into a zip archive
+func (a *Analyzer) exportCSVZip(ctx context.Context, filename string) error {
+	file, err := os.Create(filename) // #nosec G304 - path validated by exportCSV
+	if err != nil {
+		return fmt.Errorf(errMsgFailedToCreateFile, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			// Log error but don't return it as we're in defer
+			_, _ = fmt.Fprintf(a.progressWriter(), errMsgFailedToCloseFile, closeErr)
+		}
+	}()
+
+	archive := zip.NewWriter(file)
+	err = a.writeCSVBundle(ctx, func(name string, write func(io.Writer) error) error {
+		entry, createErr := archive.Create(name)
+		if createErr != nil {
+			return createErr
+		}
+		return write(entry)
+	})
+	if err != nil {
+		_ = archive.Close() // The bundle error is more useful
+		return err
+	}
+	return archive.Close()
+}
+
+// writeCSVBundle builds the tables and writes each one, then the manifest, through create
+func (a *Analyzer) writeCSVBundle(ctx context.Context, create func(name string, write func(io.Writer) error) error) error {
+	doc, err := a.BuildExport(ctx)
+	if err != nil {
+		return err
+	}
+
+	tables := bundleTables(doc)
+	manifest := BundleManifest{
+		SchemaVersion: ExportSchemaVersion,
+		Game:          doc.Report.Game.Key,
+		GeneratedAt:   doc.Report.GeneratedAt,
+		Tables:        make([]BundleTable, len(tables)),
+	}
+	for i, table := range tables {
+		table.Rows = len(table.rows)
+		manifest.Tables[i] = table.BundleTable
+		if err = create(table.File, table.write); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.File, err)
+		}
+	}
+
+	return create(BundleManifestFile, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(manifest)
+	})
+}
+
+// write writes the header and rows as CSV
+func (t *csvTable) write(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.header()); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(t.rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// bundleTables converts an export document into the CSV bundle tables
+func bundleTables(doc *ExportDocument) []*csvTable {
+	game := doc.Report.Game
+	return []*csvTable{
+		numberTable("numbers", "Main number frequency and gap statistics", doc.MainNumbers),
+		numberTable("lucky_balls", game.BonusName+" frequency and gap statistics", doc.LuckyBalls),
+		combinationTable("pairs", "Every pair drawn together, most frequent first", doc.PairPatterns, 2),
+		pairTestTable(doc.PairTests),
+		pairMatrixTable(doc.Report.PairMatrix),
+		combinationTable("triples", "Every triple drawn together, most frequent first", doc.TriplePatterns, 3),
+		patternTable(doc.Patterns),
+		patternFitTable(doc.Report.PatternFits),
+		recommendationTable(doc.Report.Recommendations, game.MainPicks),
+		correlationTable(doc.Report.Correlations),
+		drawingTable(doc.Drawings, game.MainPicks),
+	}
+}
+
+// newCSVTable returns an empty table whose file is named after the table
+func newCSVTable(name, description string) *csvTable {
+	return &csvTable{BundleTable: BundleTable{Name: name, File: name + ".csv", Description: description}}
+}
+
+// numberTable lists per-number statistics
+func numberTable(name, description string, infos []NumberInfo) *csvTable {
+	t := newCSVTable(name, description)
+	t.column("number", ColumnTypeInteger)
+	t.column("total_frequency", ColumnTypeInteger)
+	t.column("recent_frequency", ColumnTypeInteger)
+	t.column("expected_frequency", ColumnTypeNumber)
+	t.column("average_gap", ColumnTypeNumber)
+	t.column("gap_standard_deviation", ColumnTypeNumber)
+	t.column("current_gap", ColumnTypeInteger)
+	t.column("last_drawn", ColumnTypeDate)
+	t.column("chi_square_component", ColumnTypeNumber)
+
+	for _, info := range infos {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(info.Number),
+			strconv.Itoa(info.TotalFrequency),
+			strconv.Itoa(info.RecentFrequency),
+			formatFloat(info.ExpectedFrequency),
+			formatFloat(info.AverageGap),
+			formatFloat(info.StandardDeviation),
+			strconv.Itoa(info.CurrentGap),
+			formatDate(info.LastDrawnDate),
+			formatFloat(info.ChiSquareComponent),
+		})
+	}
+	return t
+}
+
+// combinationTable lists number combinations with one column per number
+func combinationTable(name, description string, patterns []CombinationPattern, size int) *csvTable {
+	t := newCSVTable(name, description)
+	t.column("key", ColumnTypeString)
+	for i := 1; i <= size; i++ {
+		t.column(fmt.Sprintf("number_%d", i), ColumnTypeInteger)
+	}
+	t.column("frequency", ColumnTypeInteger)
+	t.column("last_seen_index", ColumnTypeInteger)
+
+	for _, pattern := range patterns {
+		row := []string{pattern.Key}
+		row = append(row, formatInts(pattern.Numbers, size)...)
+		row = append(row, strconv.Itoa(pattern.Frequency), strconv.Itoa(pattern.LastSeen))
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// pairTestTable lists the binomial test of every pair of main numbers
+func pairTestTable(tests []PairSignificance) *csvTable {
+	t := newCSVTable("pair_tests", "Every pair's co-occurrence against a fair draw, most significant first")
+	t.column("key", ColumnTypeString)
+	t.column("number_1", ColumnTypeInteger)
+	t.column("number_2", ColumnTypeInteger)
+	t.column("observed", ColumnTypeInteger)
+	t.column("expected", ColumnTypeNumber)
+	t.column("p_value", ColumnTypeNumber)
+	t.column("p_bonferroni", ColumnTypeNumber)
+	t.column("p_holm", ColumnTypeNumber)
+	t.column("p_benjamini_hochberg", ColumnTypeNumber)
+
+	for _, test := range tests {
+		row := []string{test.Key}
+		row = append(row, formatInts(test.Numbers, 2)...)
+		row = append(row, strconv.Itoa(test.Observed), formatFloat(test.Expected), formatFloat(test.PValue),
+			formatFloat(test.AdjustedPValues.Bonferroni), formatFloat(test.AdjustedPValues.Holm),
+			formatFloat(test.AdjustedPValues.BenjaminiHochberg))
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// pairMatrixTable lists the pair co-occurrence matrix with one row and one column per main number
+func pairMatrixTable(matrix PairMatrix) *csvTable {
+	t := newCSVTable("pair_matrix", "Drawings including both the row and the column number; the diagonal counts a number drawn twice")
+	t.column("number", ColumnTypeInteger)
+	for i := range matrix.Counts {
+		t.column(strconv.Itoa(matrix.MinNumber+i), ColumnTypeInteger)
+	}
+
+	for i, counts := range matrix.Counts {
+		row := []string{strconv.Itoa(matrix.MinNumber + i)}
+		for _, count := range counts {
+			row = append(row, strconv.Itoa(count))
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// patternTable lists odd/even, sum range, decade and consecutive counts in long format
+func patternTable(stats PatternStats) *csvTable {
+	t := newCSVTable("patterns", "Pattern counts in long format: odd_even, sum_range (bucket start), decade (bucket start) and consecutive")
+	t.column("pattern_type", ColumnTypeString)
+	t.column("pattern", ColumnTypeString)
+	t.column("count", ColumnTypeInteger)
+
+	for _, pattern := range sortPatternCounts(stats.OddEvenPatterns) {
+		t.rows = append(t.rows, []string{"odd_even", pattern.Pattern, strconv.Itoa(pattern.Count)})
+	}
+	for _, bucket := range sortedKeys(stats.SumRanges) {
+		t.rows = append(t.rows, []string{"sum_range", strconv.Itoa(bucket), strconv.Itoa(stats.SumRanges[bucket])})
+	}
+	for _, bucket := range sortedKeys(stats.DecadeDistribution) {
+		t.rows = append(t.rows, []string{"decade", strconv.Itoa(bucket), strconv.Itoa(stats.DecadeDistribution[bucket])})
+	}
+	t.rows = append(t.rows, []string{"consecutive", "any", strconv.Itoa(stats.ConsecutiveCount)})
+	return t
+}
+
+// patternFitTable lists the observed and expected counts of every pattern family in long format
+func patternFitTable(fits []PatternFit) *csvTable {
+	t := newCSVTable("pattern_fits", "Observed and fair-draw expected counts of the odd_even, sum_range and decade patterns")
+	t.column("pattern_type", ColumnTypeString)
+	t.column("pattern", ColumnTypeString)
+	t.column("observed", ColumnTypeInteger)
+	t.column("expected", ColumnTypeNumber)
+	t.column("probability", ColumnTypeNumber)
+	t.column("p_value", ColumnTypeNumber) // Of the family's goodness-of-fit test, repeated on each row
+
+	for _, fit := range fits {
+		for _, bin := range fit.Bins {
+			t.rows = append(t.rows, []string{fit.Family, bin.Pattern, strconv.Itoa(bin.Observed),
+				formatFloat(bin.Expected), formatFloat(bin.Probability), formatFloat(fit.Test.PValue)})
+		}
+	}
+	return t
+}
+
+// recommendationTable lists the recommended sets
+func recommendationTable(recommendations []RecommendedSet, picks int) *csvTable {
+	t := newCSVTable("recommendations", "Recommended number sets")
+	t.column("rank", ColumnTypeInteger)
+	t.column("strategy", ColumnTypeString)
+	for i := 1; i <= picks; i++ {
+		t.column(fmt.Sprintf("number_%d", i), ColumnTypeInteger)
+	}
+	t.column("lucky_ball", ColumnTypeInteger)
+	t.column("expected_return", ColumnTypeNumber)
+	t.column("roi", ColumnTypeNumber)
+	t.column("any_prize_probability", ColumnTypeNumber)
+	t.column("explanation", ColumnTypeString)
+
+	for i, rec := range recommendations {
+		row := []string{strconv.Itoa(i + 1), rec.Strategy}
+		row = append(row, formatInts(rec.Numbers, picks)...)
+		row = append(row, strconv.Itoa(rec.LuckyBall))
+		if rec.Value != nil {
+			row = append(row, formatFloat(rec.Value.ExpectedReturn), formatFloat(rec.Value.ROI), formatFloat(rec.Value.AnyPrizeProbability))
+		} else {
+			row = append(row, "", "", "")
+		}
+		row = append(row, rec.Explanation)
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// correlationTable lists the cosmic correlation results
+func correlationTable(results []CorrelationResult) *csvTable {
+	t := newCSVTable("correlations", "Cosmic factor correlation results")
+	t.column("factor", ColumnTypeString)
+	t.column("sub_factor", ColumnTypeString)
+	t.column("correlation", ColumnTypeNumber)
+	t.column("p_value", ColumnTypeNumber)
+	t.column("ci_lower", ColumnTypeNumber)
+	t.column("ci_upper", ColumnTypeNumber)
+	t.column("ci_level", ColumnTypeNumber)
+	t.column("p_bonferroni", ColumnTypeNumber)
+	t.column("p_holm", ColumnTypeNumber)
+	t.column("p_benjamini_hochberg", ColumnTypeNumber)
+	t.column("sample_size", ColumnTypeInteger)
+	t.column("significance", ColumnTypeString)
+	t.column("interpretation", ColumnTypeString)
+
+	for _, result := range results {
+		ciLower, ciUpper, ciLevel := "", "", ""
+		if ci := result.ConfidenceInterval; ci != nil {
+			ciLower, ciUpper, ciLevel = formatFloat(ci.Lower), formatFloat(ci.Upper), formatFloat(ci.Level)
+		}
+		t.rows = append(t.rows, []string{
+			result.Factor,
+			result.SubFactor,
+			formatFloat(result.Correlation),
+			formatFloat(result.PValue),
+			ciLower,
+			ciUpper,
+			ciLevel,
+			formatFloat(result.AdjustedPValues.Bonferroni),
+			formatFloat(result.AdjustedPValues.Holm),
+			formatFloat(result.AdjustedPValues.BenjaminiHochberg),
+			strconv.Itoa(result.SampleSize),
+			result.Significance,
+			result.Interpretation,
+		})
+	}
+	return t
+}
+
+// drawingTable lists each drawing with its derived and cosmic attributes
+func drawingTable(drawings []ExportDrawing, picks int) *csvTable {
+	t := newCSVTable("drawings_enriched", "Drawing history, most recent first, with sums, parity and cosmic data")
+	t.column("index", ColumnTypeInteger)
+	t.column("date", ColumnTypeDate)
+	for i := 1; i <= picks; i++ {
+		t.column(fmt.Sprintf("number_%d", i), ColumnTypeInteger)
+	}
+	t.column("lucky_ball", ColumnTypeInteger)
+	t.column("sum", ColumnTypeInteger)
+	t.column("odd_count", ColumnTypeInteger)
+	t.column("moon_phase", ColumnTypeNumber)
+	t.column("moon_phase_name", ColumnTypeString)
+	t.column("moon_illumination", ColumnTypeNumber)
+	t.column("zodiac_sign", ColumnTypeString)
+	t.column("day_of_week", ColumnTypeString)
+	t.column("seasonal_phase", ColumnTypeString)
+	t.column("geomagnetic_index", ColumnTypeNumber)
+
+	for _, drawing := range drawings {
+		sum, odd := 0, 0
+		for _, num := range drawing.Numbers {
+			sum += num
+			if num%2 == 1 {
+				odd++
+			}
+		}
+
+		row := []string{strconv.Itoa(drawing.Index), formatDate(drawing.Date)}
+		row = append(row, formatInts(drawing.Numbers, picks)...)
+		row = append(row, strconv.Itoa(drawing.LuckyBall), strconv.Itoa(sum), strconv.Itoa(odd))
+		if cosmic := drawing.Cosmic; cosmic != nil {
+			row = append(row,
+				formatFloat(cosmic.MoonPhase),
+				cosmic.MoonPhaseName,
+				formatFloat(cosmic.MoonIllumination),
+				cosmic.ZodiacSign,
+				cosmic.DayOfWeek,
+				cosmic.SeasonalPhase,
+				formatFloat(cosmic.GeomagneticIndex),
+			)
+		} else {
+			row = append(row, "", "", "", "", drawing.Date.Weekday().String(), "", "")
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+// formatInts formats exactly size numbers, leaving missing ones empty
+func formatInts(numbers []int, size int) []string {
+	out := make([]string, size)
+	for i := 0; i < size && i < len(numbers); i++ {
+		out[i] = strconv.Itoa(numbers[i])
+	}
+	return out
+}
+
+// formatFloat formats a number with the shortest exact representation
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatDate formats a date as YYYY-MM-DD, or empty for the zero time
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateFormatISO)
+}
+
+// sortedKeys returns the keys of a count map in ascending order
+func sortedKeys(counts map[int]int) []int {
+	keys := make([]int, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
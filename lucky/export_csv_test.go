@@ -0,0 +1,118 @@
+package lucky
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// exportBundle exports the test analysis as a CSV bundle to name inside a temp directory
+func (s *AnalyzerTestSuite) exportBundle(name string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.analyzer.config.ExportFormat = ExportFormatCSV
+	s.analyzer.SetProgressWriter(nil)
+	s.Require().NoError(s.analyzer.ExportAnalysis(context.Background(), path))
+	return path
+}
+
+// checkBundle asserts that every manifest table exists with the described header and row count
+func (s *AnalyzerTestSuite) checkBundle(bundle fs.FS) BundleManifest {
+	file, err := bundle.Open(BundleManifestFile)
+	s.Require().NoError(err)
+	var manifest BundleManifest
+	s.Require().NoError(json.NewDecoder(file).Decode(&manifest))
+	s.Require().NoError(file.Close())
+
+	s.Equal(ExportSchemaVersion, manifest.SchemaVersion)
+	s.Equal(GameLuckyForLife, manifest.Game)
+
+	files := make([]string, 0, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		files = append(files, table.File)
+
+		file, err = bundle.Open(table.File)
+		s.Require().NoError(err, table.File)
+		records, err := csv.NewReader(file).ReadAll()
+		s.Require().NoError(err, table.File)
+		s.Require().NoError(file.Close())
+
+		s.Require().NotEmpty(records, table.File)
+		s.Len(records[0], len(table.Columns), table.File)
+		for i, col := range table.Columns {
+			s.Equal(col.Name, records[0][i], table.File)
+		}
+		s.Len(records[1:], table.Rows, table.File)
+	}
+	s.Equal([]string{
+		"numbers.csv", "lucky_balls.csv", "pairs.csv", "pair_tests.csv", "pair_matrix.csv", "triples.csv",
+		"patterns.csv", "pattern_fits.csv", "recommendations.csv", "correlations.csv", "drawings_enriched.csv",
+	}, files)
+	return manifest
+}
+
+// TestExportCSVBundleDir tests the directory CSV bundle
+func (s *AnalyzerTestSuite) TestExportCSVBundleDir() {
+	dir := s.exportBundle("bundle")
+	manifest := s.checkBundle(os.DirFS(dir))
+
+	rows := make(map[string]int)
+	for _, table := range manifest.Tables {
+		rows[table.Name] = table.Rows
+	}
+	s.Equal(48, rows["numbers"])
+	s.Equal(18, rows["lucky_balls"])
+	s.Equal(len(s.analyzer.PairPatterns()), rows["pairs"])
+	s.Equal(48*47/2, rows["pair_tests"])
+	s.Equal(48, rows["pair_matrix"])
+	s.Equal(6+12+5, rows["pattern_fits"]) // Odd/even splits, sum ranges 0-19 to 220-239 and decades
+	s.Equal(len(s.analyzer.TriplePatterns()), rows["triples"])
+	s.Equal(reportRecommendationCount, rows["recommendations"])
+	s.Equal(5, rows["drawings_enriched"])
+	s.Positive(rows["correlations"])
+	s.Positive(rows["patterns"])
+}
+
+// TestExportCSVBundleZip tests the zip CSV bundle
+func (s *AnalyzerTestSuite) TestExportCSVBundleZip() {
+	archive, err := zip.OpenReader(s.exportBundle("bundle.zip"))
+	s.Require().NoError(err)
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	s.checkBundle(archive)
+}
+
+// TestCSVTables tests the derived columns of the bundle tables
+func (s *AnalyzerTestSuite) TestCSVTables() {
+	doc, err := s.analyzer.BuildExport(context.Background())
+	s.Require().NoError(err)
+
+	drawings := drawingTable(doc.Drawings, 5)
+	first := doc.Drawings[0]
+	sum, odd := 0, 0
+	for _, num := range first.Numbers {
+		sum += num
+		odd += num % 2
+	}
+	s.Equal(formatDate(first.Date), drawings.rows[0][1])
+	s.Equal([]string{strconv.Itoa(sum), strconv.Itoa(odd)}, drawings.rows[0][8:10])
+
+	// The matrix is symmetric, with one column per number after the row's number
+	matrix := pairMatrixTable(doc.Report.PairMatrix)
+	s.Len(matrix.Columns, 49)
+	s.Equal("23", matrix.Columns[23].Name)
+	s.Equal("2", matrix.rows[22][34]) // 23 and 34 were drawn together twice
+	s.Equal("2", matrix.rows[33][23])
+	s.Equal("0", matrix.rows[22][23])
+
+	// Missing numbers leave their columns empty
+	s.Equal([]string{"1", "2", ""}, formatInts([]int{1, 2}, 3))
+	s.Empty(formatDate(time.Time{}))
+}
@@ -0,0 +1,112 @@
+package lucky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+)
+
+// exportDocument exports the test analysis as JSON and returns the file path
+func (s *AnalyzerTestSuite) exportDocument() string {
+	filename := filepath.Join(s.T().TempDir(), "analysis.json")
+	s.analyzer.config.ExportFormat = ExportFormatJSON
+	s.analyzer.SetProgressWriter(nil)
+	s.Require().NoError(s.analyzer.ExportAnalysis(context.Background(), filename))
+	return filename
+}
+
+// TestExportDocument tests that the JSON export round-trips the full analysis
+func (s *AnalyzerTestSuite) TestExportDocument() {
+	doc, err := LoadExport(s.exportDocument())
+	s.Require().NoError(err)
+
+	s.Equal(ExportSchemaVersion, doc.SchemaVersion)
+	s.Equal(s.analyzer.Config(), doc.Config)
+	s.Len(doc.MainNumbers, s.analyzer.Game().MainPoolSize)
+	s.Len(doc.LuckyBalls, s.analyzer.Game().BonusPoolSize)
+	s.Equal(1, doc.MainNumbers[0].Number)
+	s.Len(doc.PairPatterns, len(s.analyzer.PairPatterns()))
+	s.Len(doc.TriplePatterns, len(s.analyzer.TriplePatterns()))
+	s.Len(doc.QuadPatterns, len(s.analyzer.QuadPatterns()))
+	s.NotEmpty(doc.Patterns.DecadeDistribution)
+
+	// Drawings carry their cosmic data
+	s.Require().Len(doc.Drawings, 5)
+	for _, drawing := range doc.Drawings {
+		s.Require().NotNil(drawing.Cosmic)
+		s.Equal(drawing.Date.Format(dateFormatISO), drawing.Cosmic.Date.Format(dateFormatISO))
+		s.NotEmpty(drawing.Cosmic.MoonPhaseName)
+	}
+
+	// The rehydrated report can be rendered like a live one
+	s.Require().NotNil(doc.Report)
+	s.Len(doc.Report.Recommendations, reportRecommendationCount)
+	s.NotEmpty(doc.Report.Correlations)
+	var buf bytes.Buffer
+	s.Require().NoError(StatisticalRenderer{}.Render(&buf, doc.Report))
+	s.Contains(buf.String(), "STATISTICAL ANALYSIS REPORT")
+}
+
+// TestExportMatchesSchema tests that every field the schema requires is exported
+func (s *AnalyzerTestSuite) TestExportMatchesSchema() {
+	var schema map[string]interface{}
+	s.Require().NoError(json.Unmarshal(ExportSchema(), &schema))
+	defs, ok := schema["$defs"].(map[string]interface{})
+	s.Require().True(ok)
+
+	doc, err := LoadExport(s.exportDocument())
+	s.Require().NoError(err)
+	raw, err := json.Marshal(doc)
+	s.Require().NoError(err)
+	var value interface{}
+	s.Require().NoError(json.Unmarshal(raw, &value))
+
+	s.checkRequired(defs, schema, value, "$")
+}
+
+// checkRequired walks value alongside its schema and asserts every required property is present
+func (s *AnalyzerTestSuite) checkRequired(defs, schema map[string]interface{}, value interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, found := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+		s.Require().True(found, "unknown $ref %s at %s", ref, path)
+		schema = def
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			s.Contains(v, name, "missing %s.%s", path, name)
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, child := range v {
+			if prop, ok := properties[name].(map[string]interface{}); ok {
+				s.checkRequired(defs, prop, child, path+"."+name)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for _, item := range v {
+				s.checkRequired(defs, items, item, path+"[]")
+			}
+		}
+	}
+}
+
+// TestReadExportErrors tests that malformed and incompatible exports are rejected
+func (s *AnalyzerTestSuite) TestReadExportErrors() {
+	_, err := ReadExport(strings.NewReader("{not json"))
+	s.Require().ErrorIs(err, ErrInvalidExport)
+
+	_, err = ReadExport(strings.NewReader(`{"schema_version":"0","report":{}}`))
+	s.Require().ErrorIs(err, ErrUnsupportedSchemaVersion)
+
+	_, err = ReadExport(strings.NewReader(`{"schema_version":"` + ExportSchemaVersion + `"}`))
+	s.Require().ErrorIs(err, ErrInvalidExport)
+
+	_, err = LoadExport(filepath.Join(s.T().TempDir(), "missing.json"))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to open file")
+}
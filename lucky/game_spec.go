@@ -0,0 +1,220 @@
+package lucky
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnknownGame indicates a game key that has no built-in specification
+var ErrUnknownGame = errors.New("unknown game")
+
+// ErrInvalidGameSpec indicates a game specification that cannot be analyzed
+var ErrInvalidGameSpec = errors.New("invalid game spec")
+
+const (
+	// Built-in game keys
+	GameLuckyForLife = "lucky-for-life"
+	GamePowerball    = "powerball"
+	GameMegaMillions = "mega-millions"
+	GameCash5        = "cash5"
+	GamePick3        = "pick3"
+	GamePick4        = "pick4"
+
+	// DefaultGame is used when no game is configured
+	DefaultGame = GameLuckyForLife
+)
+
+// GameSpec describes the rules of a lottery game
+type GameSpec struct {
+	Key           string         `json:"key"`             // Identifier used by the --game flag
+	Name          string         `json:"name"`            // Human-readable game name
+	MinNumber     int            `json:"min_number"`      // Lowest main number (0 for digit games)
+	MainPoolSize  int            `json:"main_pool_size"`  // How many distinct main numbers exist
+	MainPicks     int            `json:"main_picks"`      // How many main numbers are drawn
+	AllowRepeats  bool           `json:"allow_repeats"`   // Whether a main number can be drawn twice (digit games)
+	BonusName     string         `json:"bonus_name"`      // Display name of the bonus ball
+	BonusPoolSize int            `json:"bonus_pool_size"` // How many bonus numbers exist (0 = no bonus ball)
+	BonusPicks    int            `json:"bonus_picks"`     // How many bonus numbers are drawn
+	DrawDays      []time.Weekday `json:"draw_days"`       // Days of the week with a drawing
+	DataFile      string         `json:"data_file"`       // Default history file name
+}
+
+// everyDay lists all weekdays for daily games
+func everyDay() []time.Weekday {
+	return []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday,
+	}
+}
+
+// luckyForLifeSpec returns the Lucky for Life rules (5 of 48 plus 1 of 18 Lucky Balls)
+func luckyForLifeSpec() *GameSpec {
+	return &GameSpec{
+		Key:           GameLuckyForLife,
+		Name:          "Lucky for Life",
+		MinNumber:     1,
+		MainPoolSize:  48,
+		MainPicks:     5,
+		BonusName:     "Lucky Ball",
+		BonusPoolSize: 18,
+		BonusPicks:    1,
+		DrawDays:      everyDay(),
+		DataFile:      "lucky-numbers-history.csv",
+	}
+}
+
+// BuiltinGameSpecs returns the specifications of all supported games keyed by game key
+func BuiltinGameSpecs() map[string]*GameSpec {
+	return map[string]*GameSpec{
+		GameLuckyForLife: luckyForLifeSpec(),
+		GamePowerball: {
+			Key:           GamePowerball,
+			Name:          "Powerball",
+			MinNumber:     1,
+			MainPoolSize:  69,
+			MainPicks:     5,
+			BonusName:     "Powerball",
+			BonusPoolSize: 26,
+			BonusPicks:    1,
+			DrawDays:      []time.Weekday{time.Monday, time.Wednesday, time.Saturday},
+			DataFile:      "powerball-history.csv",
+		},
+		GameMegaMillions: {
+			Key:           GameMegaMillions,
+			Name:          "Mega Millions",
+			MinNumber:     1,
+			MainPoolSize:  70,
+			MainPicks:     5,
+			BonusName:     "Mega Ball",
+			BonusPoolSize: 24,
+			BonusPicks:    1,
+			DrawDays:      []time.Weekday{time.Tuesday, time.Friday},
+			DataFile:      "mega-millions-history.csv",
+		},
+		GameCash5: {
+			Key:          GameCash5,
+			Name:         "Cash 5",
+			MinNumber:    1,
+			MainPoolSize: 43,
+			MainPicks:    5,
+			DrawDays:     everyDay(),
+			DataFile:     "cash5-history.csv",
+		},
+		GamePick3: {
+			Key:          GamePick3,
+			Name:         "Pick 3",
+			MinNumber:    0,
+			MainPoolSize: 10,
+			MainPicks:    3,
+			AllowRepeats: true,
+			DrawDays:     everyDay(),
+			DataFile:     "pick3-history.csv",
+		},
+		GamePick4: {
+			Key:          GamePick4,
+			Name:         "Pick 4",
+			MinNumber:    0,
+			MainPoolSize: 10,
+			MainPicks:    4,
+			AllowRepeats: true,
+			DrawDays:     everyDay(),
+			DataFile:     "pick4-history.csv",
+		},
+	}
+}
+
+// GameKeys returns the sorted keys of all built-in games
+func GameKeys() []string {
+	specs := BuiltinGameSpecs()
+	keys := make([]string, 0, len(specs))
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LookupGameSpec returns the built-in specification for a game key (case-insensitive)
+func LookupGameSpec(key string) (*GameSpec, error) {
+	if key == "" {
+		key = DefaultGame
+	}
+	spec, ok := BuiltinGameSpecs()[strings.ToLower(strings.TrimSpace(key))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %s)", ErrUnknownGame, key, strings.Join(GameKeys(), ", "))
+	}
+	return spec, nil
+}
+
+// Validate checks that the specification describes an analyzable game
+func (g *GameSpec) Validate() error {
+	switch {
+	case g.MainPoolSize <= 0:
+		return fmt.Errorf("%w: main pool size must be positive", ErrInvalidGameSpec)
+	case g.MainPicks <= 0:
+		return fmt.Errorf("%w: main picks must be positive", ErrInvalidGameSpec)
+	case !g.AllowRepeats && g.MainPicks > g.MainPoolSize:
+		return fmt.Errorf("%w: cannot pick %d of %d numbers", ErrInvalidGameSpec, g.MainPicks, g.MainPoolSize)
+	case g.BonusPicks < 0 || g.BonusPicks > 1:
+		return fmt.Errorf("%w: at most one bonus ball is supported", ErrInvalidGameSpec)
+	case g.BonusPicks > 0 && g.BonusPoolSize <= 0:
+		return fmt.Errorf("%w: bonus pool size must be positive", ErrInvalidGameSpec)
+	}
+	return nil
+}
+
+// MaxNumber returns the highest main number
+func (g *GameSpec) MaxNumber() int {
+	return g.MinNumber + g.MainPoolSize - 1
+}
+
+// HasBonus reports whether the game draws a bonus ball
+func (g *GameSpec) HasBonus() bool {
+	return g.BonusPicks > 0 && g.BonusPoolSize > 0
+}
+
+// MainDF returns the chi-square degrees of freedom for main numbers
+func (g *GameSpec) MainDF() int {
+	return g.MainPoolSize - 1
+}
+
+// BonusDF returns the chi-square degrees of freedom for bonus balls
+func (g *GameSpec) BonusDF() int {
+	if !g.HasBonus() {
+		return 0
+	}
+	return g.BonusPoolSize - 1
+}
+
+// RecordWidth returns the number of CSV columns a drawing row needs (date + numbers + bonus)
+func (g *GameSpec) RecordWidth() int {
+	return 1 + g.MainPicks + g.BonusPicks
+}
+
+// ValidMain reports whether a number is inside the main pool
+func (g *GameSpec) ValidMain(num int) bool {
+	return num >= g.MinNumber && num <= g.MaxNumber()
+}
+
+// highThreshold returns the boundary above which a main number counts as "high" (30 for a 48-ball pool)
+func (g *GameSpec) highThreshold() int {
+	return g.MinNumber - 1 + g.MainPoolSize*5/8
+}
+
+// ValidBonus reports whether a number is inside the bonus pool
+func (g *GameSpec) ValidBonus(num int) bool {
+	return num >= 1 && num <= g.BonusPoolSize
+}
+
+// DrawsOn reports whether the game has a drawing on the given weekday
+func (g *GameSpec) DrawsOn(day time.Weekday) bool {
+	for _, d := range g.DrawDays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
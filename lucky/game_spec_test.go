@@ -0,0 +1,151 @@
+package lucky
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// writeGameFixture writes a CSV fixture for a game test and registers cleanup
+func (s *AnalyzerTestSuite) writeGameFixture(name, content string) string {
+	err := os.WriteFile(name, []byte(content), 0o600)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = os.Remove(name) })
+	return name
+}
+
+// TestLookupGameSpec tests resolving built-in game specifications
+func (s *AnalyzerTestSuite) TestLookupGameSpec() {
+	// Empty key resolves to the default game
+	spec, err := LookupGameSpec("")
+	s.Require().NoError(err)
+	s.Equal(GameLuckyForLife, spec.Key)
+	s.Equal(48, spec.MainPoolSize)
+	s.Equal(5, spec.MainPicks)
+	s.Equal(18, spec.BonusPoolSize)
+
+	// Keys are case-insensitive
+	spec, err = LookupGameSpec(" PowerBall ")
+	s.Require().NoError(err)
+	s.Equal(GamePowerball, spec.Key)
+	s.Equal(69, spec.MaxNumber())
+	s.True(spec.DrawsOn(time.Wednesday))
+	s.False(spec.DrawsOn(time.Thursday))
+
+	// Unknown games list the available keys
+	_, err = LookupGameSpec("keno")
+	s.Require().ErrorIs(err, ErrUnknownGame)
+	s.Contains(err.Error(), GameMegaMillions)
+
+	// Every built-in spec must be valid and carry a data file
+	for _, key := range GameKeys() {
+		spec, err = LookupGameSpec(key)
+		s.Require().NoError(err)
+		s.Require().NoError(spec.Validate(), "spec %s", key)
+		s.NotEmpty(spec.DataFile)
+		s.NotEmpty(spec.DrawDays)
+	}
+}
+
+// TestGameSpecValidate tests rejection of unusable specifications
+func (s *AnalyzerTestSuite) TestGameSpecValidate() {
+	testCases := []GameSpec{
+		{MainPoolSize: 0, MainPicks: 5},
+		{MainPoolSize: 10, MainPicks: 0},
+		{MainPoolSize: 4, MainPicks: 5},
+		{MainPoolSize: 48, MainPicks: 5, BonusPoolSize: 18, BonusPicks: 2},
+		{MainPoolSize: 48, MainPicks: 5, BonusPicks: 1},
+	}
+	for _, tc := range testCases {
+		s.ErrorIs(tc.Validate(), ErrInvalidGameSpec)
+	}
+
+	// Digit games may pick more numbers than the pool when repeats are allowed
+	digits := GameSpec{MainPoolSize: 3, MainPicks: 4, AllowRepeats: true}
+	s.NoError(digits.Validate())
+}
+
+// TestNewAnalyzerUnknownGame tests that an unknown game is rejected
+func (s *AnalyzerTestSuite) TestNewAnalyzerUnknownGame() {
+	_, err := NewAnalyzer(context.Background(), s.testFile, &AnalysisConfig{Game: "keno"})
+	s.Require().ErrorIs(err, ErrUnknownGame)
+}
+
+// TestPowerballAnalysis tests analysis driven by the Powerball specification
+func (s *AnalyzerTestSuite) TestPowerballAnalysis() {
+	file := s.writeGameFixture("test_powerball.csv", `Date,N1,N2,N3,N4,N5,Powerball
+01/15/2024,5,12,23,64,69,26
+01/13/2024,3,15,22,38,44,12
+01/10/2024,5,18,23,35,70,7
+01/08/2024,1,1,23,34,41,3`)
+
+	analyzer, err := NewAnalyzer(context.Background(), file, &AnalysisConfig{Game: GamePowerball})
+	s.Require().NoError(err)
+
+	// Rows with out-of-range or repeated numbers are skipped
+	s.Len(analyzer.drawings, 2)
+	s.Len(analyzer.mainNumbers, 69)
+	s.Len(analyzer.luckyBalls, 26)
+	s.Equal(1, analyzer.luckyBalls[26].TotalFrequency)
+	s.InDelta(2.0*5/69, analyzer.mainNumbers[1].ExpectedFrequency, 0.0001)
+
+	recommendations, err := analyzer.GenerateRecommendations(context.Background(), 5)
+	s.Require().NoError(err)
+	for _, rec := range recommendations {
+		s.Len(rec.Numbers, 5)
+		s.LessOrEqual(rec.LuckyBall, 26)
+	}
+
+	pick := analyzer.correlationEngine.PredictBasedOnCosmicConditions()
+	s.Len(pick.Numbers, 5)
+	for _, num := range pick.Numbers {
+		s.GreaterOrEqual(num, 1)
+		s.LessOrEqual(num, 69)
+	}
+	s.True(analyzer.spec().ValidBonus(pick.LuckyBall), pick.LuckyBall)
+}
+
+// TestCash5Analysis tests a game without a bonus ball
+func (s *AnalyzerTestSuite) TestCash5Analysis() {
+	file := s.writeGameFixture("test_cash5.csv", `Date,N1,N2,N3,N4,N5
+01/15/2024,5,12,23,34,43
+01/14/2024,3,15,22,38,41`)
+
+	analyzer, err := NewAnalyzer(context.Background(), file, &AnalysisConfig{Game: GameCash5})
+	s.Require().NoError(err)
+	s.Len(analyzer.drawings, 2)
+	s.Empty(analyzer.luckyBalls)
+	s.GreaterOrEqual(analyzer.randomnessScore, 0.0)
+
+	recommendations, err := analyzer.GenerateRecommendations(context.Background(), 2)
+	s.Require().NoError(err)
+	for _, rec := range recommendations {
+		s.Zero(rec.LuckyBall)
+	}
+
+	s.Require().NoError(analyzer.RunAnalysis(context.Background()))
+}
+
+// TestPick3Analysis tests a digit game that allows repeated numbers
+func (s *AnalyzerTestSuite) TestPick3Analysis() {
+	file := s.writeGameFixture("test_pick3.csv", `Date,D1,D2,D3
+01/15/2024,0,0,7
+01/14/2024,9,1,9
+01/13/2024,10,1,2`)
+
+	analyzer, err := NewAnalyzer(context.Background(), file, &AnalysisConfig{Game: GamePick3})
+	s.Require().NoError(err)
+	s.Len(analyzer.drawings, 2)
+	s.Len(analyzer.mainNumbers, 10)
+	s.Equal(2, analyzer.mainNumbers[0].TotalFrequency)
+	s.Equal(2, analyzer.mainNumbers[9].TotalFrequency)
+
+	pick := analyzer.correlationEngine.PredictBasedOnCosmicConditions()
+	s.Zero(pick.LuckyBall) // Pick 3 has no bonus ball
+	numbers := pick.Numbers
+	s.Len(numbers, 3)
+	for _, num := range numbers {
+		s.GreaterOrEqual(num, 0)
+		s.LessOrEqual(num, 9)
+	}
+}
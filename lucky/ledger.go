@@ -0,0 +1,316 @@
+package lucky
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLedgerEntry indicates a ticket that cannot be recorded in the ledger
+var ErrInvalidLedgerEntry = errors.New("invalid ledger entry")
+
+// ErrUnsupportedLedgerVersion indicates a ledger file written by an incompatible version
+var ErrUnsupportedLedgerVersion = errors.New("unsupported ledger version")
+
+const (
+	// LedgerVersion is the ledger file format version
+	LedgerVersion = 1
+
+	// ledgerDirPerm is the permission of directories created for the ledger file
+	ledgerDirPerm = 0o750
+
+	// ledgerFilePerm is the permission of the ledger file
+	ledgerFilePerm = 0o600
+)
+
+// Ledger is a record of tickets bought, who paid for them and what they won
+type Ledger struct {
+	Version int           `json:"version"`
+	Entries []LedgerEntry `json:"entries"`
+}
+
+// LedgerEntry is one ticket played in every drawing from FirstDraw through LastDraw
+type LedgerEntry struct {
+	ID           int            `json:"id"`
+	Owner        string         `json:"owner"`
+	Game         string         `json:"game"`
+	Ticket       Ticket         `json:"ticket"`
+	PurchaseDate time.Time      `json:"purchase_date"`
+	FirstDraw    time.Time      `json:"first_draw"`
+	LastDraw     time.Time      `json:"last_draw"`
+	Cost         float64        `json:"cost"`
+	Results      []LedgerResult `json:"results"` // Covered drawings reconciled so far, oldest first
+}
+
+// LedgerResult is how a ledger ticket fared in one covered drawing
+type LedgerResult struct {
+	Drawing Drawing      `json:"drawing"`
+	Result  TicketResult `json:"result"`
+}
+
+// OwnerPosition is one person's totals across their ledger tickets
+type OwnerPosition struct {
+	Owner        string  `json:"owner"`
+	Tickets      int     `json:"tickets"`
+	Spent        float64 `json:"spent"`
+	Won          float64 `json:"won"`
+	Net          float64 `json:"net"`
+	PendingDraws int     `json:"pending_draws"` // Covered drawings not reconciled yet
+}
+
+// LoadLedger reads a ledger file; a missing file is an empty ledger
+func LoadLedger(path string) (*Ledger, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - the caller chooses the ledger file
+	if errors.Is(err, os.ErrNotExist) {
+		return &Ledger{Version: LedgerVersion, Entries: []LedgerEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger: %w", err)
+	}
+
+	var ledger Ledger
+	if err = json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to decode ledger %s: %w", path, err)
+	}
+	if ledger.Version != LedgerVersion {
+		return nil, fmt.Errorf("%w: %d (expected %d)", ErrUnsupportedLedgerVersion, ledger.Version, LedgerVersion)
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = []LedgerEntry{}
+	}
+	return &ledger, nil
+}
+
+// Save writes the ledger to path, replacing the previous file only once the new one is complete
+func (l *Ledger) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode ledger: %w", err)
+	}
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, ledgerDirPerm); err != nil {
+		return fmt.Errorf("failed to create ledger directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".ledger-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create ledger: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name()) // No-op once renamed
+	}()
+	if _, err = tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	if err = tmp.Chmod(ledgerFilePerm); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write ledger: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace ledger: %w", err)
+	}
+	return nil
+}
+
+// Add validates an entry for game and records it with the next free ID.
+// A zero Cost is filled in from the game's ticket price and the drawings covered.
+func (l *Ledger) Add(game *GameSpec, entry LedgerEntry) (LedgerEntry, error) {
+	entry.Owner = strings.TrimSpace(entry.Owner)
+	switch {
+	case entry.Owner == "":
+		return LedgerEntry{}, fmt.Errorf("%w: owner is required", ErrInvalidLedgerEntry)
+	case entry.FirstDraw.IsZero() || entry.LastDraw.Before(entry.FirstDraw):
+		return LedgerEntry{}, fmt.Errorf("%w: last draw %s is before first draw %s", ErrInvalidLedgerEntry,
+			entry.LastDraw.Format(dateFormatISO), entry.FirstDraw.Format(dateFormatISO))
+	case entry.Cost < 0:
+		return LedgerEntry{}, fmt.Errorf("%w: cost must not be negative", ErrInvalidLedgerEntry)
+	}
+	if err := ValidateTicket(game, entry.Ticket); err != nil {
+		return LedgerEntry{}, err
+	}
+
+	covered := len(drawDates(game, entry.FirstDraw, entry.LastDraw))
+	if covered == 0 {
+		return LedgerEntry{}, fmt.Errorf("%w: %s has no drawing from %s to %s", ErrInvalidLedgerEntry, game.Name,
+			entry.FirstDraw.Format(dateFormatISO), entry.LastDraw.Format(dateFormatISO))
+	}
+	if entry.Cost == 0 {
+		prizes, err := PrizeTableFor(game.Key)
+		if err != nil {
+			return LedgerEntry{}, fmt.Errorf("%w: cost is required: %w", ErrInvalidLedgerEntry, err)
+		}
+		entry.Cost = prizes.TicketPrice * float64(covered)
+	}
+
+	entry.Game = game.Key
+	entry.Results = []LedgerResult{}
+	for _, existing := range l.Entries {
+		entry.ID = max(entry.ID, existing.ID)
+	}
+	entry.ID++
+	l.Entries = append(l.Entries, entry)
+	return entry, nil
+}
+
+// Reconcile scores every ticket of the analyzed game against the covered drawings in the history
+// and returns how many drawing results were added. Running it again with the same history changes nothing.
+func (l *Ledger) Reconcile(a *Analyzer) int {
+	game := a.spec()
+	prizes, _ := a.PrizeTable() // Nil for games without known prizes
+	added := 0
+	for i := range l.Entries {
+		entry := &l.Entries[i]
+		if entry.Game != game.Key {
+			continue
+		}
+
+		results := []LedgerResult{}
+		for _, drawing := range a.drawings {
+			if !coversDate(entry.FirstDraw, entry.LastDraw, drawing.Date) {
+				continue
+			}
+			result := TicketResult{
+				MainMatches: countMatches(entry.Ticket.Numbers, drawing.Numbers),
+				BonusMatch:  game.HasBonus() && entry.Ticket.LuckyBall == drawing.LuckyBall,
+			}
+			if prizes != nil {
+				result = prizes.Evaluate(entry.Ticket, drawing)
+			}
+			results = append(results, LedgerResult{Drawing: drawing, Result: result})
+		}
+		sort.Slice(results, func(x, y int) bool {
+			return results[x].Drawing.Date.Before(results[y].Drawing.Date)
+		})
+
+		added += max(0, len(results)-len(entry.Results))
+		entry.Results = results
+	}
+	return added
+}
+
+// Won returns the cash value of every prize the entry has won so far
+func (e *LedgerEntry) Won() float64 {
+	won := 0.0
+	for _, result := range e.Results {
+		won += result.Result.CashValue
+	}
+	return won
+}
+
+// PendingDraws returns how many covered drawings have not been reconciled yet
+func (e *LedgerEntry) PendingDraws() int {
+	game, err := LookupGameSpec(e.Game)
+	if err != nil {
+		return 0
+	}
+	return max(0, len(drawDates(game, e.FirstDraw, e.LastDraw))-len(e.Results))
+}
+
+// Positions returns each owner's spend, winnings and net position, ordered by owner
+func (l *Ledger) Positions() []OwnerPosition {
+	byOwner := make(map[string]*OwnerPosition)
+	for i := range l.Entries {
+		entry := &l.Entries[i]
+		position, ok := byOwner[entry.Owner]
+		if !ok {
+			position = &OwnerPosition{Owner: entry.Owner}
+			byOwner[entry.Owner] = position
+		}
+		position.Tickets++
+		position.Spent += entry.Cost
+		position.Won += entry.Won()
+		position.PendingDraws += entry.PendingDraws()
+	}
+
+	positions := make([]OwnerPosition, 0, len(byOwner))
+	for _, position := range byOwner {
+		position.Net = position.Won - position.Spent
+		positions = append(positions, *position)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Owner < positions[j].Owner })
+	return positions
+}
+
+// Records returns the ledger as NDJSON records: every entry followed by every owner's position
+func (l *Ledger) Records() []Record {
+	records := make([]Record, 0, len(l.Entries))
+	for _, entry := range l.Entries {
+		records = append(records, Record{Type: RecordTypeLedgerEntry, Data: entry})
+	}
+	for _, position := range l.Positions() {
+		records = append(records, Record{Type: RecordTypeLedgerPosition, Data: position})
+	}
+	return records
+}
+
+// drawDates returns the days from first through last on which game has a drawing
+func drawDates(game *GameSpec, first, last time.Time) []time.Time {
+	var dates []time.Time
+	day := truncateToDay(first)
+	for end := truncateToDay(last); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if game.DrawsOn(day.Weekday()) {
+			dates = append(dates, day)
+		}
+	}
+	return dates
+}
+
+// coversDate reports whether date falls on a day from first through last
+func coversDate(first, last, date time.Time) bool {
+	day := truncateToDay(date)
+	return !day.Before(truncateToDay(first)) && !day.After(truncateToDay(last))
+}
+
+// truncateToDay returns midnight UTC of the calendar day of t
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WriteLedger writes the ledger's tickets and each owner's position as console tables
+func WriteLedger(w io.Writer, ledger *Ledger) error {
+	c := &consoleWriter{w: w}
+	c.section("📒 TICKET LEDGER")
+	if len(ledger.Entries) == 0 {
+		c.println("No tickets recorded yet.")
+		return c.err
+	}
+
+	c.printf("%4s  %-12s  %-24s  %-21s  %9s  %9s  %s\n", "ID", "Owner", "Ticket", "Draws", "Cost", "Won", "Status")
+	for i := range ledger.Entries {
+		entry := &ledger.Entries[i]
+		game, err := LookupGameSpec(entry.Game)
+		ticket := fmt.Sprint(entry.Ticket.Numbers)
+		if err == nil {
+			ticket = formatTicket(*game, entry.Ticket)
+		}
+		status := "settled"
+		if pending := entry.PendingDraws(); pending > 0 {
+			status = fmt.Sprintf("%d pending", pending)
+		}
+		for _, result := range entry.Results {
+			if result.Result.Tier != nil {
+				status += fmt.Sprintf(", %s on %s", result.Result.Tier.Name, result.Drawing.Date.Format("01/02/2006"))
+			}
+		}
+		c.printf("%4d  %-12s  %-24s  %s-%s  %9.2f  %9.2f  %s\n", entry.ID, entry.Owner, ticket,
+			entry.FirstDraw.Format("01/02/2006"), entry.LastDraw.Format("01/02/2006"), entry.Cost, entry.Won(), status)
+	}
+
+	c.println("\nPositions:")
+	c.printf("%-12s  %7s  %9s  %9s  %10s  %s\n", "Owner", "Tickets", "Spent", "Won", "Net", "Pending draws")
+	for _, position := range ledger.Positions() {
+		c.printf("%-12s  %7d  %9.2f  %9.2f  %10.2f  %d\n", position.Owner, position.Tickets,
+			position.Spent, position.Won, position.Net, position.PendingDraws)
+	}
+	return c.err
+}
@@ -0,0 +1,126 @@
+package lucky
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TestLedgerAdd tests validating ledger entries and pricing them from the draws they cover
+func (s *AnalyzerTestSuite) TestLedgerAdd() {
+	game := luckyForLifeSpec()
+	ledger := &Ledger{Version: LedgerVersion}
+	first := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	ticket := Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7}
+
+	entry, err := ledger.Add(game, LedgerEntry{Owner: " alice ", Ticket: ticket, FirstDraw: first, LastDraw: first.AddDate(0, 0, 3)})
+	s.Require().NoError(err)
+	s.Equal(1, entry.ID)
+	s.Equal("alice", entry.Owner)
+	s.Equal(GameLuckyForLife, entry.Game)
+	s.InDelta(8.0, entry.Cost, 0) // Four daily drawings at $2
+	s.Equal(4, entry.PendingDraws())
+
+	entry, err = ledger.Add(game, LedgerEntry{Owner: "bob", Ticket: ticket, FirstDraw: first, LastDraw: first, Cost: 5})
+	s.Require().NoError(err)
+	s.Equal(2, entry.ID)
+	s.InDelta(5.0, entry.Cost, 0)
+
+	invalid := []LedgerEntry{
+		{Ticket: ticket, FirstDraw: first, LastDraw: first},
+		{Owner: "carol", Ticket: ticket, FirstDraw: first, LastDraw: first.AddDate(0, 0, -1)},
+		{Owner: "carol", Ticket: ticket, LastDraw: first},
+		{Owner: "carol", Ticket: ticket, FirstDraw: first, LastDraw: first, Cost: -2},
+	}
+	for _, bad := range invalid {
+		_, err = ledger.Add(game, bad)
+		s.Require().ErrorIs(err, ErrInvalidLedgerEntry)
+	}
+	_, err = ledger.Add(game, LedgerEntry{Owner: "carol", Ticket: Ticket{Numbers: []int{1, 2, 3}}, FirstDraw: first, LastDraw: first})
+	s.Require().ErrorIs(err, ErrInvalidTicket)
+
+	// Powerball draws three days a week and has no prize table, so a Sunday needs a price
+	powerball, err := LookupGameSpec(GamePowerball)
+	s.Require().NoError(err)
+	sunday := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	pbTicket := Ticket{Numbers: []int{1, 2, 3, 4, 5}, LuckyBall: 6}
+	_, err = ledger.Add(powerball, LedgerEntry{Owner: "carol", Ticket: pbTicket, FirstDraw: sunday, LastDraw: sunday, Cost: 2})
+	s.Require().ErrorIs(err, ErrInvalidLedgerEntry)
+	_, err = ledger.Add(powerball, LedgerEntry{Owner: "carol", Ticket: pbTicket, FirstDraw: sunday, LastDraw: sunday.AddDate(0, 0, 1)})
+	s.Require().ErrorIs(err, ErrNoPrizeTable)
+	s.Len(ledger.Entries, 2)
+}
+
+// TestLedgerReconcile tests scoring ledger tickets against the drawings they cover
+func (s *AnalyzerTestSuite) TestLedgerReconcile() {
+	game := luckyForLifeSpec()
+	ledger := &Ledger{Version: LedgerVersion}
+	first := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	_, err := ledger.Add(game, LedgerEntry{Owner: "alice", Ticket: Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7},
+		FirstDraw: first, LastDraw: first.AddDate(0, 0, 3)})
+	s.Require().NoError(err)
+	_, err = ledger.Add(game, LedgerEntry{Owner: "bob", Ticket: Ticket{Numbers: []int{1, 2, 4, 6, 8}, LuckyBall: 3},
+		FirstDraw: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), LastDraw: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)})
+	s.Require().NoError(err)
+
+	// The fixture covers 01/12 and 01/15 for alice and all five drawings for bob
+	s.Equal(7, ledger.Reconcile(s.analyzer))
+	alice := ledger.Entries[0]
+	s.Require().Len(alice.Results, 2)
+	s.True(alice.Results[0].Drawing.Date.Before(alice.Results[1].Drawing.Date))
+	s.Require().NotNil(alice.Results[1].Result.Tier)
+	s.Equal("5+LB", alice.Results[1].Result.Tier.Name)
+	s.Equal(2, alice.PendingDraws())
+
+	// Bob matched 2 and the Lucky Ball on 01/03: 1+LB pays $6
+	s.InDelta(6.0, ledger.Entries[1].Won(), 0)
+
+	// Reconciling the same history again adds nothing
+	s.Zero(ledger.Reconcile(s.analyzer))
+
+	positions := ledger.Positions()
+	s.Require().Len(positions, 2)
+	s.Equal("alice", positions[0].Owner)
+	s.InDelta(8.0, positions[0].Spent, 0)
+	s.InDelta(5_750_000.0, positions[0].Won, 0)
+	s.InDelta(positions[0].Won-positions[0].Spent, positions[0].Net, 1e-9)
+	s.Equal("bob", positions[1].Owner)
+	s.InDelta(62.0, positions[1].Spent, 0)
+	s.InDelta(-56.0, positions[1].Net, 1e-9)
+	s.Equal(26, positions[1].PendingDraws)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteLedger(&buf, ledger))
+	s.Contains(buf.String(), "TICKET LEDGER")
+	s.Contains(buf.String(), "5+LB on 01/15/2024")
+	s.Contains(buf.String(), "Positions:")
+}
+
+// TestLedgerSaveLoad tests round-tripping the ledger file
+func (s *AnalyzerTestSuite) TestLedgerSaveLoad() {
+	path := filepath.Join(s.T().TempDir(), "nested", "ledger.json")
+
+	// A missing file is an empty ledger
+	ledger, err := LoadLedger(path)
+	s.Require().NoError(err)
+	s.Empty(ledger.Entries)
+
+	first := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	_, err = ledger.Add(luckyForLifeSpec(), LedgerEntry{Owner: "alice", Ticket: Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7},
+		PurchaseDate: first, FirstDraw: first, LastDraw: first})
+	s.Require().NoError(err)
+	ledger.Reconcile(s.analyzer)
+	s.Require().NoError(ledger.Save(path))
+
+	loaded, err := LoadLedger(path)
+	s.Require().NoError(err)
+	s.Require().Len(loaded.Entries, 1)
+	s.Equal(ledger.Entries[0].Ticket, loaded.Entries[0].Ticket)
+	s.True(first.Equal(loaded.Entries[0].PurchaseDate))
+	s.InDelta(ledger.Positions()[0].Won, loaded.Positions()[0].Won, 0)
+
+	s.Require().NoError(os.WriteFile(path, []byte(`{"version": 99}`), 0o600))
+	_, err = LoadLedger(path)
+	s.Require().ErrorIs(err, ErrUnsupportedLedgerVersion)
+}
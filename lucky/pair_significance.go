@@ -0,0 +1,282 @@
+package lucky
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// PairSignificance is how often two main numbers were drawn together against a fair draw
+type PairSignificance struct {
+	Numbers         []int           `json:"numbers"`
+	Key             string          `json:"key"`
+	Observed        int             `json:"observed"` // Drawings with both numbers, or with the number twice for a repeated pair
+	Expected        float64         `json:"expected"`
+	PValue          float64         `json:"p_value"`           // Two-sided exact binomial test
+	AdjustedPValues AdjustedPValues `json:"adjusted_p_values"` // PValue corrected for every pair tested
+}
+
+// PairMatrix counts how often every pair of main numbers was drawn together and lists the pairs a fair
+// draw does not explain, at the analysis confidence level after false discovery rate control
+type PairMatrix struct {
+	MinNumber      int                 `json:"min_number"`
+	Drawings       int                 `json:"drawings"`
+	Counts         [][]int             `json:"counts"`                    // Counts[i][j] counts drawings with MinNumber+i and MinNumber+j
+	Expected       float64             `json:"expected"`                  // Expected count of each pair of different numbers
+	ExpectedRepeat float64             `json:"expected_repeat,omitempty"` // Expected count of a number drawn twice, for games that repeat numbers
+	Comparisons    MultipleComparisons `json:"comparisons"`               // Pairs significant before and after each correction
+	Unusual        []PairSignificance  `json:"unusual"`                   // Benjamini–Hochberg significant, most significant first
+}
+
+// PairTests returns the binomial test of every pair of main numbers, most significant first
+func (a *Analyzer) PairTests() []PairSignificance {
+	tests := make([]PairSignificance, 0, len(a.testPairs()))
+	for _, test := range a.testPairs() {
+		tests = append(tests, test.clone())
+	}
+	return tests
+}
+
+// PairMatrix returns the co-occurrence counts of every pair of main numbers with the unusual pairs
+func (a *Analyzer) PairMatrix() PairMatrix {
+	game := a.spec()
+	pair, repeat := pairProbability(game)
+	drawings := len(a.drawings)
+	tests := a.testPairs()
+	matrix := PairMatrix{
+		MinNumber:      game.MinNumber,
+		Drawings:       drawings,
+		Counts:         a.pairCounts(),
+		Expected:       pair * float64(drawings),
+		ExpectedRepeat: repeat * float64(drawings),
+		Comparisons:    MultipleComparisons{Tests: len(tests), Alpha: significanceLevel(a.confidenceLevel())},
+		Unusual:        []PairSignificance{},
+	}
+	for _, test := range tests {
+		matrix.Comparisons.count(test.PValue, test.AdjustedPValues)
+		if test.AdjustedPValues.BenjaminiHochberg < matrix.Comparisons.Alpha {
+			matrix.Unusual = append(matrix.Unusual, test.clone())
+		}
+	}
+	return matrix
+}
+
+// clone returns a copy of the test that shares no memory with it
+func (p PairSignificance) clone() PairSignificance {
+	p.Numbers = append([]int(nil), p.Numbers...)
+	return p
+}
+
+// testPairs returns the binomial test of every pair, built once: each drawing includes a pair with the
+// same chance, so its count is binomial over the drawings. Pairs of a repeated number are tested only
+// for games that can repeat one.
+func (a *Analyzer) testPairs() []PairSignificance {
+	if a.pairTests != nil {
+		return a.pairTests
+	}
+
+	game := a.spec()
+	pair, repeat := pairProbability(game)
+	drawings := len(a.drawings)
+	counts := a.pairCounts()
+	tests := make([]PairSignificance, 0, len(counts)*(len(counts)+1)/2)
+	for i := range counts {
+		first := i + 1
+		if game.AllowRepeats {
+			first = i
+		}
+		for j := first; j < len(counts); j++ {
+			p := pair
+			if i == j {
+				p = repeat
+			}
+			numbers := []int{game.MinNumber + i, game.MinNumber + j}
+			tests = append(tests, PairSignificance{
+				Numbers:  numbers,
+				Key:      fmt.Sprintf("%d-%d", numbers[0], numbers[1]),
+				Observed: counts[i][j],
+				Expected: p * float64(drawings),
+				PValue:   BinomialTwoSidedPValue(counts[i][j], drawings, p),
+			})
+		}
+	}
+
+	pValues := make([]float64, len(tests))
+	for i, test := range tests {
+		pValues[i] = test.PValue
+	}
+	for i, adjusted := range adjustPValues(pValues) {
+		tests[i].AdjustedPValues = adjusted
+	}
+	sort.SliceStable(tests, func(i, j int) bool { return cleanPValue(tests[i].PValue) < cleanPValue(tests[j].PValue) })
+	a.pairTests = tests
+	return tests
+}
+
+// pairCounts counts the drawings that include each pair of main numbers, indexed from the game's
+// lowest number. The diagonal counts drawings with the number at least twice.
+func (a *Analyzer) pairCounts() [][]int {
+	game := a.spec()
+	counts := make([][]int, game.MainPoolSize)
+	for i := range counts {
+		counts[i] = make([]int, game.MainPoolSize)
+	}
+
+	multiplicity := make([]int, game.MainPoolSize)
+	for _, drawing := range a.drawings {
+		clear(multiplicity)
+		for _, num := range drawing.Numbers {
+			if game.ValidMain(num) {
+				multiplicity[num-game.MinNumber]++
+			}
+		}
+		for i, times := range multiplicity {
+			if times == 0 {
+				continue
+			}
+			if times > 1 {
+				counts[i][i]++
+			}
+			for j := i + 1; j < len(multiplicity); j++ {
+				if multiplicity[j] > 0 {
+					counts[i][j]++
+					counts[j][i]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// pairProbability returns the chance that one drawing includes two given different numbers, and the
+// chance that it includes a given number at least twice (zero for games that never repeat one)
+func pairProbability(game *GameSpec) (pair, repeat float64) {
+	n, k := float64(game.MainPoolSize), float64(game.MainPicks)
+	if !game.AllowRepeats {
+		if game.MainPoolSize < 2 {
+			return 0, 0
+		}
+		return k * (k - 1) / (n * (n - 1)), 0
+	}
+	// Inclusion–exclusion over drawings that miss either number
+	missOne := math.Pow(1-1/n, k)
+	pair = 1 - 2*missOne + math.Pow(1-2/n, k)
+	repeat = 1 - missOne - k/n*math.Pow(1-1/n, k-1)
+	return pair, repeat
+}
+
+// excessPairs returns the pairs drawn together significantly more often than a fair draw allows,
+// after false discovery rate control at the analysis confidence level
+func (a *Analyzer) excessPairs() []PairSignificance {
+	alpha := significanceLevel(a.confidenceLevel())
+	var excess []PairSignificance
+	for _, test := range a.testPairs() {
+		if test.AdjustedPValues.BenjaminiHochberg >= alpha {
+			break // Tests run from the smallest p-value, and adjusted p-values never decrease along them
+		}
+		if float64(test.Observed) > test.Expected {
+			excess = append(excess, test)
+		}
+	}
+	return excess
+}
+
+// expected returns the expected count of the pair of MinNumber+i and MinNumber+j
+func (m *PairMatrix) expected(i, j int) float64 {
+	if i == j {
+		return m.ExpectedRepeat
+	}
+	return m.Expected
+}
+
+// unusual returns the matrix's unusual pair with the given key, or nil when the pair is within chance
+func (m *PairMatrix) unusual(key string) *PairSignificance {
+	for i := range m.Unusual {
+		if m.Unusual[i].Key == key {
+			return &m.Unusual[i]
+		}
+	}
+	return nil
+}
+
+// patternStrategy plays numbers that are drawn together more often than chance allows
+type patternStrategy struct {
+	builtinStrategy
+}
+
+// newPatternStrategy returns the pattern strategy
+func newPatternStrategy() *patternStrategy {
+	return &patternStrategy{builtinStrategy{
+		name:        "pattern",
+		explanation: "Plays pairs drawn together significantly more often than a fair draw allows, after false discovery rate control",
+		score:       scorePattern,
+	}}
+}
+
+// ScoreMain scores numbers by their unusual pairs. With no unusual pair it scores none, so the strategy
+// has nothing to play rather than playing the lowest numbers.
+func (s *patternStrategy) ScoreMain(a *Analyzer) []ScoredNumber {
+	if len(a.excessPairs()) == 0 {
+		return nil
+	}
+	return s.builtinStrategy.ScoreMain(a)
+}
+
+// ScoreTicket scores a ticket by how far its unusual pairs exceed their expected counts
+func (s *patternStrategy) ScoreTicket(a *Analyzer, numbers []int) float64 {
+	excess, _ := ticketExcess(a.excessPairs(), numbers)
+	return excess
+}
+
+// Explain names the unusual pairs the set plays, or says that no pair stood out
+func (s *patternStrategy) Explain(a *Analyzer, set RecommendedSet) string {
+	pairs := a.excessPairs()
+	if len(pairs) == 0 {
+		return "No pair is drawn together more often than a fair draw allows after false discovery rate control, so no pair was favored"
+	}
+	if _, keys := ticketExcess(pairs, set.Numbers); len(keys) > 0 {
+		return s.explanation + " (plays " + strings.Join(keys, ", ") + ")"
+	}
+	return s.explanation
+}
+
+// scorePattern scores a number by how far the unusual pairs it belongs to exceed their expected counts
+func scorePattern(a *Analyzer, num int, _ *NumberInfo) (float64, []string) {
+	score, pairs := 0.0, 0
+	for _, pair := range a.excessPairs() {
+		if pair.Numbers[0] == num || pair.Numbers[1] == num {
+			score += float64(pair.Observed) - pair.Expected
+			pairs++
+		}
+	}
+	factors := []string{}
+	if pairs > 0 {
+		factors = append(factors, fmt.Sprintf("UnusualPairs-%d", pairs))
+	}
+	return score, factors
+}
+
+// ticketExcess returns how far the pairs a ticket plays exceed their expected counts, with their keys
+func ticketExcess(pairs []PairSignificance, numbers []int) (float64, []string) {
+	excess := 0.0
+	var keys []string
+	for _, pair := range pairs {
+		first, second := 0, 0
+		for _, num := range numbers {
+			if num == pair.Numbers[0] {
+				first++
+			}
+			if num == pair.Numbers[1] {
+				second++
+			}
+		}
+		// A repeated number's pair needs the number twice
+		if first == 0 || second == 0 || (pair.Numbers[0] == pair.Numbers[1] && first < 2) {
+			continue
+		}
+		excess += float64(pair.Observed) - pair.Expected
+		keys = append(keys, pair.Key)
+	}
+	return excess, keys
+}
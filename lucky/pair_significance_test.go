@@ -0,0 +1,163 @@
+package lucky
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pairedAnalyzer returns an analyzer whose drawings always include 1 and 2, with three other numbers
+// cycling through the rest of the pool
+func (s *AnalyzerTestSuite) pairedAnalyzer(drawings int) *Analyzer {
+	var csv strings.Builder
+	csv.WriteString("Date,Number 1,Number 2,Number 3,Number 4,Number 5,Lucky Ball\n")
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for d := range drawings {
+		_, _ = fmt.Fprintf(&csv, "%s,1,2,%d,%d,%d,%d\n", start.AddDate(0, 0, -d).Format("01/02/2006"),
+			3+(3*d)%45, 3+(3*d+1)%45, 3+(3*d+2)%45, d%18+1)
+	}
+	analyzer, err := NewAnalyzerFromReader(context.Background(), strings.NewReader(csv.String()),
+		&AnalysisConfig{RecentWindow: 10, ConfidenceLevel: 0.95})
+	s.Require().NoError(err)
+	analyzer.SetProgressWriter(nil)
+	return analyzer
+}
+
+// TestPairMatrix tests the co-occurrence counts and binomial tests of every pair
+func (s *AnalyzerTestSuite) TestPairMatrix() {
+	matrix := s.analyzer.PairMatrix()
+	s.Require().Len(matrix.Counts, 48)
+	s.Equal(1, matrix.MinNumber)
+	s.Equal(5, matrix.Drawings)
+	s.InDelta(5*5*4/(48*47.0), matrix.Expected, 1e-12)
+	s.Zero(matrix.ExpectedRepeat)
+
+	// The matrix is symmetric and agrees with the pair patterns
+	total := 0
+	for i, row := range matrix.Counts {
+		s.Zero(row[i])
+		for j := i + 1; j < len(row); j++ {
+			s.Equal(row[j], matrix.Counts[j][i])
+			total += row[j]
+		}
+	}
+	s.Equal(5*10, total)
+	for key, pattern := range s.analyzer.PairPatterns() {
+		s.Equal(pattern.Frequency, matrix.Counts[pattern.Numbers[0]-1][pattern.Numbers[1]-1], key)
+	}
+
+	tests := s.analyzer.PairTests()
+	s.Require().Len(tests, 48*47/2)
+	for i := 1; i < len(tests); i++ {
+		s.LessOrEqual(tests[i-1].PValue, tests[i].PValue)
+		s.LessOrEqual(tests[i-1].AdjustedPValues.BenjaminiHochberg, tests[i].AdjustedPValues.BenjaminiHochberg)
+	}
+	s.Contains([]string{"5-23", "23-34"}, tests[0].Key) // The only pairs drawn twice
+	s.Equal(2, tests[0].Observed)
+	s.InDelta(BinomialTwoSidedPValue(2, 5, 5*4/(48*47.0)), tests[0].PValue, 1e-15)
+
+	// Five drawings cannot make any of 1,128 pairs stand out
+	s.Empty(matrix.Unusual)
+	s.Equal(MultipleComparisons{Tests: 1128, Alpha: 0.05}, MultipleComparisons{
+		Tests: matrix.Comparisons.Tests, Alpha: matrix.Comparisons.Alpha, BenjaminiHochberg: matrix.Comparisons.BenjaminiHochberg,
+	})
+	s.Empty(s.analyzer.excessPairs())
+}
+
+// TestPairProbability tests the chance of a pair in one drawing against enumerating every draw
+func (s *AnalyzerTestSuite) TestPairProbability() {
+	pair, repeat := pairProbability(luckyForLifeSpec())
+	s.InDelta(20/2256.0, pair, 1e-15)
+	s.Zero(repeat)
+
+	// Ordered draws of 3 digits from 0-3 that include 0 and 1, or 0 at least twice
+	game := &GameSpec{MinNumber: 0, MainPoolSize: 4, MainPicks: 3, AllowRepeats: true}
+	both, twice := 0, 0
+	for d := range 64 {
+		zeros, ones := 0, 0
+		for _, digit := range []int{d / 16, d / 4 % 4, d % 4} {
+			switch digit {
+			case 0:
+				zeros++
+			case 1:
+				ones++
+			}
+		}
+		if zeros > 0 && ones > 0 {
+			both++
+		}
+		if zeros > 1 {
+			twice++
+		}
+	}
+	pair, repeat = pairProbability(game)
+	s.InDelta(float64(both)/64, pair, 1e-15)
+	s.InDelta(float64(twice)/64, repeat, 1e-15)
+}
+
+// TestPatternStrategy tests that the pattern strategy rewards only pairs chance does not explain
+func (s *AnalyzerTestSuite) TestPatternStrategy() {
+	pattern, err := s.analyzer.Strategies().Lookup("pattern")
+	s.Require().NoError(err)
+	analyzer := s.pairedAnalyzer(40)
+	matrix := analyzer.PairMatrix()
+	s.Require().Len(matrix.Unusual, 1)
+	s.Equal("1-2", matrix.Unusual[0].Key)
+	s.Equal(40, matrix.Unusual[0].Observed)
+	s.Less(matrix.Unusual[0].AdjustedPValues.BenjaminiHochberg, 1e-20)
+
+	scorer, ok := pattern.(TicketScorer)
+	s.Require().True(ok)
+	s.InDelta(40-matrix.Expected, scorer.ScoreTicket(analyzer, []int{1, 2, 10, 20, 30}), 1e-9)
+	s.Zero(scorer.ScoreTicket(analyzer, []int{1, 3, 10, 20, 30}))
+
+	analyzer.config.Strategies = []string{"pattern"}
+	sets, err := analyzer.GenerateRecommendations(context.Background(), 1)
+	s.Require().NoError(err)
+	s.Subset(sets[0].Numbers, []int{1, 2})
+	s.Contains(sets[0].Explanation, "(plays 1-2)")
+
+	output := s.renderReport(analyzer, StatisticalRenderer{})
+	s.Contains(output, "Pair Co-occurrence Heatmap")
+	s.Contains(output, "    1  +")
+	s.Contains(output, "1-2: 40 times")
+	output = s.renderReport(analyzer, DetailedRenderer{})
+	s.Contains(output, "1-2: 40 times - unusual")
+	s.Contains(output, "Unusual pairs: 1 of 1128")
+}
+
+// TestPatternStrategyWithoutUnusualPairs tests that the pattern strategy plays nothing when every pair
+// is within chance, rather than playing the lowest numbers
+func (s *AnalyzerTestSuite) TestPatternStrategyWithoutUnusualPairs() {
+	pattern, err := s.analyzer.Strategies().Lookup("pattern")
+	s.Require().NoError(err)
+	s.Require().Empty(s.analyzer.excessPairs())
+	s.Empty(pattern.ScoreMain(s.analyzer))
+	s.Contains(pattern.Explain(s.analyzer, RecommendedSet{Numbers: []int{5, 12, 23, 34, 45}}), "no pair was favored")
+
+	ctx := context.Background()
+	s.analyzer.config.Strategies = []string{"pattern"}
+	sets, err := s.analyzer.GenerateRecommendations(ctx, 2)
+	s.Require().ErrorIs(err, ErrNothingToPlay)
+	s.Empty(sets)
+
+	// The other strategies fill the skipped sets
+	s.analyzer.config.Strategies = []string{"pattern", "hot"}
+	sets, err = s.analyzer.GenerateRecommendations(ctx, 3)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 3)
+	for _, set := range sets {
+		s.Equal("hot", set.Strategy)
+	}
+	s.NotEqual(sets[0].Numbers, sets[1].Numbers)
+
+	s.analyzer.config.Strategies = nil
+	sets, err = s.analyzer.GenerateRecommendations(ctx, 6)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 6)
+	for _, set := range sets {
+		s.NotEqual("pattern", set.Strategy)
+		s.NotEqual([]int{1, 2, 3, 4, 5}, set.Numbers)
+	}
+}
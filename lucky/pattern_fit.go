@@ -0,0 +1,219 @@
+package lucky
+
+import (
+	"fmt"
+	"math"
+)
+
+// Pattern families compared against their exact distribution under a fair draw
+const (
+	PatternFamilyOddEven = "odd_even"  // Odd/even split of a drawing
+	PatternFamilySum     = "sum_range" // Sum of a drawing's main numbers, in sumRangeWidth buckets
+	PatternFamilyDecade  = "decade"    // Decade of each drawn main number
+)
+
+// sumRangeWidth is the width of the sum range buckets
+const sumRangeWidth = 20
+
+// PatternBin is one outcome of a pattern family with its observed and expected counts
+type PatternBin struct {
+	Pattern     string  `json:"pattern"`
+	Observed    int     `json:"observed"`
+	Expected    float64 `json:"expected"`
+	Probability float64 `json:"probability"` // Chance of the outcome for one counted drawing or number
+}
+
+// PatternFit compares how often a pattern family's outcomes occurred with a fair draw. Bins expecting
+// too few counts are merged with their neighbors for the chi-square test.
+type PatternFit struct {
+	Family string        `json:"family"`
+	Total  int           `json:"total"` // Drawings counted, or drawn numbers for decades
+	Bins   []PatternBin  `json:"bins"`
+	Test   ChiSquareTest `json:"test"`
+}
+
+// PatternFits compares the odd/even, sum range and decade patterns with their exact distributions
+func (a *Analyzer) PatternFits() []PatternFit {
+	game := a.spec()
+	patterns := a.PatternStats()
+	drawings := len(a.drawings)
+	confidence := a.confidenceLevel()
+
+	oddEven := oddEvenDistribution(game)
+	oddEvenFit := PatternFit{Family: PatternFamilyOddEven, Total: drawings}
+	for odd, probability := range oddEven {
+		label := fmt.Sprintf("%dO-%dE", odd, game.MainPicks-odd)
+		oddEvenFit.Bins = append(oddEvenFit.Bins, newPatternBin(label, patterns.OddEvenPatterns[label], drawings, probability))
+	}
+	oddEvenFit.Test = fitPatternBins(oddEvenFit.Bins, 1, confidence)
+
+	sums := sumDistribution(game)
+	sumFit := PatternFit{Family: PatternFamilySum, Total: drawings}
+	for bucket := minSum(game) / sumRangeWidth * sumRangeWidth; bucket < len(sums); bucket += sumRangeWidth {
+		probability := 0.0
+		for sum := bucket; sum < min(bucket+sumRangeWidth, len(sums)); sum++ {
+			probability += sums[sum]
+		}
+		label := fmt.Sprintf("%d-%d", bucket, bucket+sumRangeWidth-1)
+		sumFit.Bins = append(sumFit.Bins, newPatternBin(label, patterns.SumRanges[bucket], drawings, probability))
+	}
+	sumFit.Test = fitPatternBins(sumFit.Bins, 1, confidence)
+
+	// Every drawn number is equally likely to be any number of the pool, so a decade expects its share of
+	// the pool. The numbers of one drawing are distinct, which makes the counts vary less than multinomial
+	// counts would: the finite population correction scales the statistic back to a chi-square.
+	decadeFit := PatternFit{Family: PatternFamilyDecade, Total: drawings * game.MainPicks}
+	maxNumber := game.MaxNumber()
+	for bucket := 0; bucket <= decadeBucket(game, maxNumber); bucket++ {
+		low := game.MinNumber + bucket*10
+		high := min(low+9, maxNumber)
+		probability := float64(high-low+1) / float64(game.MainPoolSize)
+		label := fmt.Sprintf("%d-%d", low, high)
+		decadeFit.Bins = append(decadeFit.Bins, newPatternBin(label, patterns.DecadeDistribution[bucket], decadeFit.Total, probability))
+	}
+	correction := 1.0
+	if !game.AllowRepeats && game.MainPoolSize > game.MainPicks {
+		correction = float64(game.MainPoolSize-1) / float64(game.MainPoolSize-game.MainPicks)
+	}
+	decadeFit.Test = fitPatternBins(decadeFit.Bins, correction, confidence)
+
+	return []PatternFit{oddEvenFit, sumFit, decadeFit}
+}
+
+// newPatternBin returns a bin expecting probability of total counts
+func newPatternBin(label string, observed, total int, probability float64) PatternBin {
+	return PatternBin{Pattern: label, Observed: observed, Expected: probability * float64(total), Probability: probability}
+}
+
+// fitPatternBins tests the bins' observed counts against their expected counts, scaling the statistic by correction
+func fitPatternBins(bins []PatternBin, correction, confidence float64) ChiSquareTest {
+	observed := make([]float64, len(bins))
+	expected := make([]float64, len(bins))
+	for i, bin := range bins {
+		observed[i], expected[i] = float64(bin.Observed), bin.Expected
+	}
+	test := goodnessOfFit(observed, expected, confidence)
+	if correction == 1 {
+		return test
+	}
+	return newChiSquareTest(test.Statistic*correction, test.DegreesOfFreedom, confidence)
+}
+
+// oddEvenDistribution returns the probability of drawing k odd main numbers for every k: hypergeometric
+// over the pool's odd and even numbers, or binomial for games that can repeat a number
+func oddEvenDistribution(game *GameSpec) []float64 {
+	odd := 0
+	for num := game.MinNumber; num <= game.MaxNumber(); num++ {
+		odd += num % 2
+	}
+	even := game.MainPoolSize - odd
+
+	probabilities := make([]float64, game.MainPicks+1)
+	for k := range probabilities {
+		if game.AllowRepeats {
+			p := float64(odd) / float64(game.MainPoolSize)
+			probabilities[k] = math.Exp(logChoose(game.MainPicks, k)) * math.Pow(p, float64(k)) * math.Pow(1-p, float64(game.MainPicks-k))
+		} else {
+			probabilities[k] = math.Exp(logChoose(odd, k) + logChoose(even, game.MainPicks-k) - logChoose(game.MainPoolSize, game.MainPicks))
+		}
+	}
+	return probabilities
+}
+
+// sumDistribution returns the probability of every main number sum, indexed by the sum. It counts the
+// combinations of distinct numbers with each sum by dynamic programming, or the ordered draws for games
+// that can repeat a number.
+func sumDistribution(game *GameSpec) []float64 {
+	largest := maxSum(game)
+	// ways[j][s] counts the ways j numbers sum to s
+	ways := make([][]float64, game.MainPicks+1)
+	for j := range ways {
+		ways[j] = make([]float64, largest+1)
+	}
+	ways[0][0] = 1
+
+	if game.AllowRepeats {
+		for j := 1; j <= game.MainPicks; j++ {
+			for s := range ways[j-1] {
+				if ways[j-1][s] == 0 {
+					continue
+				}
+				for num := game.MinNumber; num <= game.MaxNumber() && s+num <= largest; num++ {
+					ways[j][s+num] += ways[j-1][s]
+				}
+			}
+		}
+	} else {
+		// Counting down from the most numbers adds each number to a combination at most once
+		for num := game.MinNumber; num <= game.MaxNumber(); num++ {
+			for j := min(game.MainPicks, num-game.MinNumber+1); j >= 1; j-- {
+				for s := largest; s >= num; s-- {
+					ways[j][s] += ways[j-1][s-num]
+				}
+			}
+		}
+	}
+
+	total := 0.0
+	for _, count := range ways[game.MainPicks] {
+		total += count
+	}
+	probabilities := ways[game.MainPicks]
+	for s := range probabilities {
+		probabilities[s] /= total
+	}
+	return probabilities
+}
+
+// minSum returns the smallest possible sum of a drawing's main numbers
+func minSum(game *GameSpec) int {
+	if game.AllowRepeats {
+		return game.MainPicks * game.MinNumber
+	}
+	return game.MainPicks*game.MinNumber + game.MainPicks*(game.MainPicks-1)/2
+}
+
+// maxSum returns the largest possible sum of a drawing's main numbers
+func maxSum(game *GameSpec) int {
+	if game.AllowRepeats {
+		return game.MainPicks * game.MaxNumber()
+	}
+	return game.MainPicks*game.MaxNumber() - game.MainPicks*(game.MainPicks-1)/2
+}
+
+// PatternFit returns the report's fit of a pattern family, or nil when the report has none
+func (r *Report) PatternFit(family string) *PatternFit {
+	for i := range r.PatternFits {
+		if r.PatternFits[i].Family == family {
+			return &r.PatternFits[i]
+		}
+	}
+	return nil
+}
+
+// bin returns the fit's bin of a pattern, or nil when the fit or bin is missing
+func (f *PatternFit) bin(pattern string) *PatternBin {
+	if f == nil {
+		return nil
+	}
+	for i := range f.Bins {
+		if f.Bins[i].Pattern == pattern {
+			return &f.Bins[i]
+		}
+	}
+	return nil
+}
+
+// patternFamilyTitle returns the console title of a pattern family
+func patternFamilyTitle(family string) string {
+	switch family {
+	case PatternFamilyOddEven:
+		return "Odd/even split"
+	case PatternFamilySum:
+		return fmt.Sprintf("Sum of the main numbers (%d-wide ranges)", sumRangeWidth)
+	case PatternFamilyDecade:
+		return "Decade of each drawn number"
+	default:
+		return family
+	}
+}
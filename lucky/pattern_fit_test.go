@@ -0,0 +1,115 @@
+package lucky
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TestPatternDistributions tests the exact odd/even and sum distributions against direct enumeration
+func (s *AnalyzerTestSuite) TestPatternDistributions() {
+	game := luckyForLifeSpec()
+	oddEven := oddEvenDistribution(game)
+	s.Require().Len(oddEven, 6)
+	s.InDelta(42_504.0/1_712_304.0, oddEven[5], 1e-15) // C(24,5) / C(48,5): the pool is half odd
+	s.InDelta(oddEven[0], oddEven[5], 1e-15)
+	s.InDelta(oddEven[2], oddEven[3], 1e-15)
+
+	sums := sumDistribution(game)
+	s.Len(sums, 231) // 1+2+3+4+5 up to 44+45+46+47+48
+	total, mean := 0.0, 0.0
+	for sum, p := range sums {
+		total += p
+		mean += float64(sum) * p
+	}
+	s.InDelta(1, total, 1e-12)
+	s.InDelta(122.5, mean, 1e-9)
+	s.InDelta(1/1_712_304.0, sums[15], 1e-18)
+
+	// Small games enumerated draw by draw, with and without repeats
+	for _, small := range []*GameSpec{
+		{Key: "small", MinNumber: 1, MainPoolSize: 12, MainPicks: 4},
+		{Key: "digits", MinNumber: 0, MainPoolSize: 10, MainPicks: 3, AllowRepeats: true},
+	} {
+		counts := make(map[int]float64)
+		oddCounts := make(map[int]float64)
+		draws := 0.0
+		var enumerate func(picked []int, next int)
+		enumerate = func(picked []int, next int) {
+			if len(picked) == small.MainPicks {
+				sum, odd := 0, 0
+				for _, num := range picked {
+					sum += num
+					odd += num % 2
+				}
+				counts[sum]++
+				oddCounts[odd]++
+				draws++
+				return
+			}
+			if small.AllowRepeats {
+				next = small.MinNumber
+			}
+			for num := next; num <= small.MaxNumber(); num++ {
+				enumerate(append(picked, num), num+1)
+			}
+		}
+		enumerate(nil, small.MinNumber)
+
+		sums = sumDistribution(small)
+		s.Len(sums, maxSum(small)+1, small.Key)
+		for sum, p := range sums {
+			s.InDelta(counts[sum]/draws, p, 1e-12, "%s sum %d", small.Key, sum)
+		}
+		for odd, p := range oddEvenDistribution(small) {
+			s.InDelta(oddCounts[odd]/draws, p, 1e-12, "%s odd %d", small.Key, odd)
+		}
+	}
+}
+
+// TestPatternFits tests the observed patterns against a fair draw, and a draw that is not
+func (s *AnalyzerTestSuite) TestPatternFits() {
+	fits := s.analyzer.PatternFits()
+	s.Require().Len(fits, 3)
+	for _, fit := range fits {
+		observed, expected := 0, 0.0
+		for _, bin := range fit.Bins {
+			observed += bin.Observed
+			expected += bin.Expected
+		}
+		s.Equal(fit.Total, observed, fit.Family)
+		s.InDelta(float64(fit.Total), expected, 1e-9, fit.Family)
+		s.False(fit.Test.RejectsUniform, fit.Family)
+	}
+
+	report, err := s.analyzer.BuildReport(context.Background())
+	s.Require().NoError(err)
+	s.Equal(fits, report.PatternFits)
+	s.Equal(4, report.PatternFit(PatternFamilyOddEven).bin("3O-2E").Observed)
+	s.Equal(3, report.PatternFit(PatternFamilySum).bin("120-139").Observed)
+	decades := report.PatternFit(PatternFamilyDecade)
+	s.Equal(25, decades.Total)
+	s.Equal("41-48", decades.Bins[4].Pattern)
+	s.InDelta(25*8/48.0, decades.Bins[4].Expected, 1e-12)
+	s.Nil(report.PatternFit("consecutive"))
+
+	output := s.renderReport(s.analyzer, StatisticalRenderer{})
+	s.Contains(output, "Pattern Distributions vs. a Fair Draw")
+	s.Contains(output, "41-48")
+
+	// Drawings of only small even numbers fail every family
+	var csv strings.Builder
+	csv.WriteString("Date,Number 1,Number 2,Number 3,Number 4,Number 5,Lucky Ball\n")
+	for day := 1; day <= 60; day++ {
+		fmt.Fprintf(&csv, "01/%02d/2020,2,4,6,8,%d,1\n", day%28+1, 10+2*(day%5))
+	}
+	biased, err := NewAnalyzerFromReader(context.Background(), strings.NewReader(csv.String()), nil)
+	s.Require().NoError(err)
+	for _, fit := range biased.PatternFits() {
+		s.True(fit.Test.RejectsUniform, fit.Family)
+		s.Less(fit.Test.PValue, 1e-6, fit.Family)
+		s.Positive(fit.Test.DegreesOfFreedom, fit.Family)
+	}
+	s.False(math.IsNaN(biased.PatternFits()[1].Test.Statistic))
+}
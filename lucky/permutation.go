@@ -0,0 +1,161 @@
+package lucky
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	// DefaultPermutations is the number of shuffles a permutation test runs when none is set
+	DefaultPermutations = 999
+
+	// DefaultPermutationSeed seeds the shuffles so repeated runs report the same p-values
+	DefaultPermutationSeed = 1
+)
+
+// PermutationTest tests whether a cosmic factor is associated with a drawing outcome.
+//
+// The outcomes are shuffled across drawings Permutations times; the p-value is the share of shuffles
+// whose statistic is at least as large as the observed one. Statistic must grow with the strength of
+// the association in either direction.
+type PermutationTest[T any] struct {
+	Factor       func(cosmic *CosmicData) (float64, bool) // Factor value of a drawing day; false skips the drawing
+	Outcome      func(drawing Drawing) T                  // Outcome of a drawing
+	Statistic    func(factors []float64, outcomes []T) float64
+	Permutations int   // Defaults to DefaultPermutations
+	Seed         int64 // Defaults to DefaultPermutationSeed
+}
+
+// PermutationResult is the outcome of a permutation test
+type PermutationResult struct {
+	Observed     float64 `json:"observed"`
+	PValue       float64 `json:"p_value"`
+	Permutations int     `json:"permutations"`
+	SampleSize   int     `json:"sample_size"`
+}
+
+// RunPermutationTest runs a permutation test over the drawings that have cosmic data
+func RunPermutationTest[T any](ce *CorrelationEngine, test PermutationTest[T]) PermutationResult {
+	var factors []float64
+	var outcomes []T
+	if ce.analyzer != nil {
+		for _, drawing := range ce.analyzer.drawings {
+			cosmic, exists := ce.cosmicData[drawing.Date.Format(dateFormatISO)]
+			if !exists {
+				continue
+			}
+			if factor, ok := test.Factor(cosmic); ok {
+				factors = append(factors, factor)
+				outcomes = append(outcomes, test.Outcome(drawing))
+			}
+		}
+	}
+	return permute(factors, outcomes, test)
+}
+
+// permute shuffles outcomes against factors and counts the shuffles that match or beat the observed statistic
+func permute[T any](factors []float64, outcomes []T, test PermutationTest[T]) PermutationResult {
+	permutations := test.Permutations
+	if permutations <= 0 {
+		permutations = DefaultPermutations
+	}
+	seed := test.Seed
+	if seed == 0 {
+		seed = DefaultPermutationSeed
+	}
+
+	result := PermutationResult{SampleSize: len(factors), PValue: 1}
+	if len(factors) < 2 {
+		return result
+	}
+	result.Observed = test.Statistic(factors, outcomes)
+	if math.IsNaN(result.Observed) {
+		return result
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(seed), uint64(seed))) //nolint:gosec // reproducible shuffles need a seeded generator, not a secure one
+	shuffled := append([]T(nil), outcomes...)
+	atLeastAsExtreme := 0
+	for i := 0; i < permutations; i++ {
+		rng.Shuffle(len(shuffled), func(a, b int) {
+			shuffled[a], shuffled[b] = shuffled[b], shuffled[a]
+		})
+		// Tolerate rounding so a shuffle that equals the observation counts as matching it
+		if test.Statistic(factors, shuffled) >= result.Observed-1e-12 {
+			atLeastAsExtreme++
+		}
+	}
+
+	// Count the observed arrangement as one of the permutations so the p-value is never zero
+	result.Permutations = permutations
+	result.PValue = float64(atLeastAsExtreme+1) / float64(permutations+1)
+	return result
+}
+
+// indicator returns a factor extractor that is 1 when match holds for the drawing day and 0 otherwise
+func indicator(match func(cosmic *CosmicData) bool) func(cosmic *CosmicData) (float64, bool) {
+	return func(cosmic *CosmicData) (float64, bool) {
+		if match(cosmic) {
+			return 1, true
+		}
+		return 0, true
+	}
+}
+
+// drawnNumbers is an outcome extractor returning the main numbers of a drawing
+func drawnNumbers(drawing Drawing) []int {
+	return drawing.Numbers
+}
+
+// topNumberShare returns the share of the most frequent number among the numbers drawn where factor is set
+func topNumberShare(factors []float64, outcomes [][]int) float64 {
+	_, share := topNumber(factors, outcomes)
+	return share
+}
+
+// topNumber returns the most frequent number drawn where factor is set and its share of those numbers
+func topNumber(factors []float64, outcomes [][]int) (number int, share float64) {
+	counts := make(map[int]int)
+	total, best := 0, 0
+	for i, numbers := range outcomes {
+		if factors[i] <= 0 {
+			continue
+		}
+		for _, num := range numbers {
+			counts[num]++
+			total++
+			// Break ties toward the lower number so the result does not depend on map order
+			if counts[num] > best || (counts[num] == best && num < number) {
+				best, number = counts[num], num
+			}
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return number, float64(best) / float64(total)
+}
+
+// meanDifference returns the absolute difference in mean outcome between drawings with and without the factor
+func meanDifference(factors, outcomes []float64) float64 {
+	return math.Abs(signedMeanDifference(factors, outcomes))
+}
+
+// signedMeanDifference returns the mean outcome with the factor minus the mean outcome without it
+func signedMeanDifference(factors, outcomes []float64) float64 {
+	var withSum, withoutSum float64
+	var with, without int
+	for i, outcome := range outcomes {
+		if factors[i] > 0 {
+			withSum += outcome
+			with++
+		} else {
+			withoutSum += outcome
+			without++
+		}
+	}
+	if with == 0 || without == 0 {
+		return math.NaN()
+	}
+	return withSum/float64(with) - withoutSum/float64(without)
+}
@@ -0,0 +1,90 @@
+package lucky
+
+import (
+	"context"
+	"math"
+)
+
+// TestPermute tests empirical p-values on associated and unrelated samples
+func (s *AnalyzerTestSuite) TestPermute() {
+	factors := make([]float64, 40)
+	associated := make([]float64, 40)
+	unrelated := make([]float64, 40)
+	for i := range factors {
+		factors[i] = float64(i % 2)
+		associated[i] = factors[i]*10 + float64(i%3)
+		unrelated[i] = float64(i / 2 % 2) // Identical for each pair of factor values
+	}
+	test := PermutationTest[float64]{Statistic: meanDifference, Permutations: 199}
+
+	// A clean split is beaten by no shuffle, so only the observed arrangement counts
+	result := permute(factors, associated, test)
+	s.Equal(199, result.Permutations)
+	s.Equal(40, result.SampleSize)
+	s.InDelta(1.0/200, result.PValue, 1e-12)
+	s.Positive(result.Observed)
+
+	// No difference at all is matched by every shuffle
+	result = permute(factors, unrelated, test)
+	s.InDelta(0.0, result.Observed, 1e-12)
+	s.InDelta(1.0, result.PValue, 1e-12)
+
+	// The same seed gives the same p-value; defaults apply when unset
+	noisy := []float64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5, 8, 9, 7, 9, 3, 2, 3, 8, 4}
+	first := permute(factors[:20], noisy, PermutationTest[float64]{Statistic: meanDifference})
+	second := permute(factors[:20], noisy, PermutationTest[float64]{Statistic: meanDifference, Seed: DefaultPermutationSeed})
+	s.Equal(DefaultPermutations, first.Permutations)
+	s.InDelta(first.PValue, second.PValue, 0)
+	s.Greater(first.PValue, 0.0)
+	s.LessOrEqual(first.PValue, 1.0)
+
+	// Degenerate samples are not evidence
+	s.InDelta(1.0, permute(nil, nil, test).PValue, 0)
+	s.InDelta(1.0, permute([]float64{1, 1, 1}, []float64{1, 2, 3}, test).PValue, 0)
+}
+
+// TestPermutationStatistics tests the statistics used by the cosmic permutation tests
+func (s *AnalyzerTestSuite) TestPermutationStatistics() {
+	factors := []float64{1, 1, 0}
+	outcomes := [][]int{{5, 7, 9}, {5, 9, 11}, {1, 1, 1}}
+	number, share := topNumber(factors, outcomes)
+	s.Equal(5, number) // 5 and 9 tie; the lower number wins
+	s.InDelta(2.0/6, share, 1e-12)
+	s.InDelta(share, topNumberShare(factors, outcomes), 0)
+
+	number, share = topNumber([]float64{0, 0, 0}, outcomes)
+	s.Zero(number)
+	s.Zero(share)
+
+	s.InDelta(-2.0, signedMeanDifference([]float64{1, 0, 1, 0}, []float64{1, 3, 1, 3}), 1e-12)
+	s.InDelta(2.0, meanDifference([]float64{1, 0, 1, 0}, []float64{1, 3, 1, 3}), 1e-12)
+	s.True(math.IsNaN(meanDifference([]float64{1, 1}, []float64{1, 3})))
+	s.Equal(2, countAbove([]int{10, 31, 48, 30}, 30))
+}
+
+// TestCosmicPermutationTests tests that grouped cosmic findings report permutation p-values
+func (s *AnalyzerTestSuite) TestCosmicPermutationTests() {
+	ctx := context.Background()
+	ce := s.analyzer.CorrelationEngine()
+	s.Require().NoError(ce.EnrichWithCosmicData(ctx))
+
+	// Every drawing has cosmic data, so an indicator test sees all of them
+	result := RunPermutationTest(ce, PermutationTest[[]int]{
+		Factor:    indicator(func(cosmic *CosmicData) bool { return cosmic.DayOfWeek == "Monday" }),
+		Outcome:   drawnNumbers,
+		Statistic: topNumberShare,
+	})
+	s.Equal(len(s.analyzer.Drawings()), result.SampleSize)
+	s.Greater(result.PValue, 0.0)
+
+	s.Require().NoError(ce.AnalyzeCorrelations(ctx))
+	for _, correlation := range ce.CorrelationResults() {
+		if correlation.ConfidenceInterval != nil {
+			continue // Pearson results have analytic p-values
+		}
+		// Empirical p-values are multiples of 1/(DefaultPermutations+1)
+		scaled := correlation.PValue * (DefaultPermutations + 1)
+		s.InDelta(math.Round(scaled), scaled, 1e-6, correlation.SubFactor)
+		s.Equal(getSignificanceLevel(correlation.PValue), correlation.Significance)
+	}
+}
@@ -0,0 +1,265 @@
+package lucky
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidPopularityModel indicates popularity weights that cannot score tickets
+var ErrInvalidPopularityModel = errors.New("invalid popularity model")
+
+// calendarDays is the highest number players pick from birthdays and anniversaries
+const calendarDays = 31
+
+// calendarMonths is the highest number players pick from birth months
+const calendarMonths = 12
+
+// PopularityModel estimates how often other players pick a number or ticket. A win on a popular ticket is
+// more likely to be shared where prizes are split, so the contrarian strategy plays unpopular ones.
+type PopularityModel struct {
+	CalendarWeight float64 `json:"calendar_weight"` // Numbers that can be a day of the month (1-31)
+	MonthWeight    float64 `json:"month_weight"`    // Numbers that can also be a month (1-12)
+	LuckyNumbers   []int   `json:"lucky_numbers"`   // Numbers favored for luck, like 7
+	LuckyWeight    float64 `json:"lucky_weight"`
+	RecentWeight   float64 `json:"recent_weight"` // Per recent appearance beyond the expected count: players chase hot numbers
+
+	BirthdayTicketWeight float64 `json:"birthday_ticket_weight"` // Every number a possible birthday
+	SequenceWeight       float64 `json:"sequence_weight"`        // Numbers in arithmetic sequence, like 5-10-15-20-25
+	SlipPatternWeight    float64 `json:"slip_pattern_weight"`    // Numbers along one row, column, diagonal or anti-diagonal of the play slip
+	SlipColumns          int     `json:"slip_columns"`           // Numbers per row of the play slip
+	PastWinnerWeight     float64 `json:"past_winner_weight"`     // The main numbers of a past drawing
+}
+
+// DefaultPopularityModel returns popularity weights following the common findings on how players pick
+func DefaultPopularityModel() *PopularityModel {
+	return &PopularityModel{
+		CalendarWeight:       1,
+		MonthWeight:          0.5,
+		LuckyNumbers:         []int{3, 7, 11},
+		LuckyWeight:          0.5,
+		RecentWeight:         0.25,
+		BirthdayTicketWeight: 2,
+		SequenceWeight:       3,
+		SlipPatternWeight:    2,
+		SlipColumns:          10,
+		PastWinnerWeight:     4,
+	}
+}
+
+// ReadPopularityModel decodes popularity weights from JSON over the defaults, so a file only needs the
+// weights it changes. Unknown fields are rejected so typos are not ignored.
+func ReadPopularityModel(r io.Reader) (*PopularityModel, error) {
+	model := DefaultPopularityModel()
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(model); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPopularityModel, err)
+	}
+	return model, model.Validate()
+}
+
+// Validate checks that every weight is a non-negative number and the slip has columns
+func (m *PopularityModel) Validate() error {
+	weights := map[string]float64{
+		"calendar_weight":        m.CalendarWeight,
+		"month_weight":           m.MonthWeight,
+		"lucky_weight":           m.LuckyWeight,
+		"recent_weight":          m.RecentWeight,
+		"birthday_ticket_weight": m.BirthdayTicketWeight,
+		"sequence_weight":        m.SequenceWeight,
+		"slip_pattern_weight":    m.SlipPatternWeight,
+		"past_winner_weight":     m.PastWinnerWeight,
+	}
+	for name, weight := range weights {
+		if !(weight >= 0) || math.IsInf(weight, 0) {
+			return fmt.Errorf("%w: %s must be a non-negative number, got %g", ErrInvalidPopularityModel, name, weight)
+		}
+	}
+	if m.SlipColumns < 1 {
+		return fmt.Errorf("%w: slip_columns must be positive, got %d", ErrInvalidPopularityModel, m.SlipColumns)
+	}
+	return nil
+}
+
+// popularity returns the configured popularity model, or the default one
+func (a *Analyzer) popularity() *PopularityModel {
+	if a.config == nil || a.config.Popularity == nil {
+		return DefaultPopularityModel()
+	}
+	return a.config.Popularity
+}
+
+// NumberPopularity estimates how popular a number is with players, with the reasons. info is the
+// number's statistics in the pool it is drawn from; recent measures against recentExpected appearances.
+func (m *PopularityModel) NumberPopularity(num int, info *NumberInfo, recentExpected float64) (float64, []string) {
+	popularity := 0.0
+	var reasons []string
+	if num >= 1 && num <= calendarDays {
+		popularity += m.CalendarWeight
+		reasons = append(reasons, "Calendar-day")
+	}
+	if num >= 1 && num <= calendarMonths {
+		popularity += m.MonthWeight
+		reasons = append(reasons, "Calendar-month")
+	}
+	for _, lucky := range m.LuckyNumbers {
+		if num == lucky {
+			popularity += m.LuckyWeight
+			reasons = append(reasons, "Lucky-number")
+			break
+		}
+	}
+	if info != nil && float64(info.RecentFrequency) > recentExpected {
+		popularity += m.RecentWeight * (float64(info.RecentFrequency) - recentExpected)
+		reasons = append(reasons, fmt.Sprintf("Recent-%d", info.RecentFrequency))
+	}
+	return popularity, reasons
+}
+
+// TicketPopularity estimates how popular a ticket's combination is beyond its numbers, with the
+// reasons: all-birthday tickets, arithmetic sequences, lines on the play slip and repeats of past
+// drawings. past indexes past drawings by their sorted main numbers.
+func (m *PopularityModel) TicketPopularity(game *GameSpec, numbers []int, past map[string]string) (float64, []string) {
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+	if len(sorted) < 3 {
+		return 0, nil
+	}
+	popularity := 0.0
+	var reasons []string
+
+	if game.MaxNumber() > calendarDays && sorted[0] >= 1 && sorted[len(sorted)-1] <= calendarDays {
+		popularity += m.BirthdayTicketWeight
+		reasons = append(reasons, "all birthday numbers")
+	}
+
+	// A sequence missing one number still looks like a sequence
+	if run, step := longestArithmeticRun(sorted); run >= max(len(sorted)-1, 3) {
+		popularity += m.SequenceWeight * float64(run-len(sorted)+2) / 2
+		reasons = append(reasons, fmt.Sprintf("%d numbers in sequence (step %d)", run, step))
+	}
+
+	if count, line := m.slipLine(game, sorted); count >= max(len(sorted)-1, 3) {
+		popularity += m.SlipPatternWeight * float64(count-len(sorted)+2) / 2
+		reasons = append(reasons, fmt.Sprintf("%d numbers along a slip %s", count, line))
+	}
+
+	if date, ok := past[joinInts(sorted)]; ok {
+		popularity += m.PastWinnerWeight
+		reasons = append(reasons, "repeats the "+date+" drawing")
+	}
+	return popularity, reasons
+}
+
+// slipLine returns the most numbers lying on one row, column, diagonal or anti-diagonal of the play slip, and
+// which kind of line
+func (m *PopularityModel) slipLine(game *GameSpec, sorted []int) (int, string) {
+	columns := max(m.SlipColumns, 1)
+	counts := make(map[string]int, 4*len(sorted))
+	best, bestLine := 0, ""
+	for _, num := range sorted {
+		row, column := (num-game.MinNumber)/columns, (num-game.MinNumber)%columns
+		for _, line := range [...]struct{ kind, key string }{
+			{"row", fmt.Sprintf("row %d", row)},
+			{"column", fmt.Sprintf("column %d", column)},
+			{"diagonal", fmt.Sprintf("diagonal %d", row-column)},
+			{"anti-diagonal", fmt.Sprintf("anti-diagonal %d", row+column)},
+		} {
+			counts[line.key]++
+			if counts[line.key] > best {
+				best, bestLine = counts[line.key], line.kind
+			}
+		}
+	}
+	return best, bestLine
+}
+
+// longestArithmeticRun returns the longest run of adjacent sorted numbers with a constant step, and the step
+func longestArithmeticRun(sorted []int) (int, int) {
+	if len(sorted) < 2 {
+		return len(sorted), 0
+	}
+	best, bestStep, run := 2, sorted[1]-sorted[0], 2
+	for i := 2; i < len(sorted); i++ {
+		if sorted[i]-sorted[i-1] == sorted[i-1]-sorted[i-2] {
+			run++
+		} else {
+			run = 2
+		}
+		if run > best {
+			best, bestStep = run, sorted[i]-sorted[i-1]
+		}
+	}
+	return best, bestStep
+}
+
+// pastDrawingIndex returns the analyzed drawings indexed by their sorted main numbers, built once
+func (a *Analyzer) pastDrawingIndex() map[string]string {
+	if a.pastIndex == nil {
+		a.pastIndex = pastTickets(a.drawings)
+	}
+	return a.pastIndex
+}
+
+// contrarianStrategy plays the numbers and tickets other players are least likely to pick
+type contrarianStrategy struct {
+	builtinStrategy
+}
+
+// newContrarianStrategy returns the contrarian strategy
+func newContrarianStrategy() *contrarianStrategy {
+	return &contrarianStrategy{builtinStrategy{
+		name:        "contrarian",
+		explanation: "Avoids the numbers and patterns other players favor, so a prize split among winners is shared less",
+		score:       scoreContrarian,
+		bonusScore:  scoreContrarianBonus,
+	}}
+}
+
+// ScoreTicket penalizes popular combinations: all-birthday tickets, sequences, slip lines and past winners
+func (s *contrarianStrategy) ScoreTicket(a *Analyzer, numbers []int) float64 {
+	popularity, _ := a.popularity().TicketPopularity(a.spec(), numbers, a.pastDrawingIndex())
+	return -popularity
+}
+
+// Explain describes the set along with any popular pattern it could not avoid
+func (s *contrarianStrategy) Explain(a *Analyzer, set RecommendedSet) string {
+	_, reasons := a.popularity().TicketPopularity(a.spec(), set.Numbers, a.pastDrawingIndex())
+	if len(reasons) == 0 {
+		return s.explanation
+	}
+	return s.explanation + " (still has " + strings.Join(reasons, ", ") + ")"
+}
+
+// scoreContrarian scores main numbers by how unpopular they are with players
+func scoreContrarian(a *Analyzer, num int, info *NumberInfo) (float64, []string) {
+	return scoreUnpopular(a, num, info, a.expectedRecent(a.spec().MainPicks, a.spec().MainPoolSize))
+}
+
+// scoreContrarianBonus scores bonus balls by how unpopular they are with players
+func scoreContrarianBonus(a *Analyzer, num int, info *NumberInfo) (float64, []string) {
+	return scoreUnpopular(a, num, info, a.expectedRecent(a.spec().BonusPicks, a.spec().BonusPoolSize))
+}
+
+// scoreUnpopular scores a number as its negated popularity, listing the popularity among the factors
+func scoreUnpopular(a *Analyzer, num int, info *NumberInfo, recentExpected float64) (float64, []string) {
+	popularity, reasons := a.popularity().NumberPopularity(num, info, recentExpected)
+	return -popularity, append([]string{fmt.Sprintf("Popularity-%.2f", popularity)}, reasons...)
+}
+
+// expectedRecent returns how often a number of a pool is expected in the recent window by chance
+func (a *Analyzer) expectedRecent(picks, poolSize int) float64 {
+	if poolSize <= 0 {
+		return 0
+	}
+	window := len(a.drawings)
+	if a.config != nil && a.config.RecentWindow > 0 {
+		window = min(window, a.config.RecentWindow)
+	}
+	return float64(window) * float64(picks) / float64(poolSize)
+}
@@ -0,0 +1,100 @@
+package lucky
+
+import (
+	"context"
+	"strings"
+)
+
+// TestPopularityModel tests number and ticket popularity and reading weights over the defaults
+func (s *AnalyzerTestSuite) TestPopularityModel() {
+	model := DefaultPopularityModel()
+	s.Require().NoError(model.Validate())
+
+	popularity, reasons := model.NumberPopularity(7, &NumberInfo{RecentFrequency: 3}, 1)
+	s.InDelta(1+0.5+0.5+0.25*2, popularity, 1e-9)
+	s.Equal([]string{"Calendar-day", "Calendar-month", "Lucky-number", "Recent-3"}, reasons)
+	popularity, reasons = model.NumberPopularity(40, &NumberInfo{}, 1)
+	s.Zero(popularity)
+	s.Empty(reasons)
+
+	game := s.analyzer.spec()
+	past := pastTickets(s.analyzer.Drawings())
+	testCases := []struct {
+		name       string
+		numbers    []int
+		popularity float64
+		reason     string
+	}{
+		{"unpopular", []int{33, 8, 46, 21, 39}, 0, ""},
+		{"birthdays", []int{3, 8, 14, 22, 29}, 2, "all birthday numbers"},
+		{"sequence", []int{35, 40, 45, 30, 25}, 3, "5 numbers in sequence (step 5)"},
+		{"almost a sequence", []int{30, 33, 36, 39, 47}, 3 / 2.0, "4 numbers in sequence (step 3)"},
+		{"slip row", []int{32, 34, 35, 38, 47}, 2 / 2.0, "4 numbers along a slip row"},
+		{"slip column", []int{4, 14, 24, 34, 41}, 3/2.0 + 2/2.0, "4 numbers along a slip column"},
+		{"slip diagonal", []int{1, 12, 23, 34, 45}, 3 + 2, "5 numbers along a slip diagonal"},
+		{"slip anti-diagonal", []int{2, 10, 19, 28, 37}, 3/2.0 + 2/2.0, "4 numbers along a slip anti-diagonal"},
+		{"past winner", []int{45, 34, 23, 12, 5}, 4 + 3/2.0 + 2/2.0, "repeats the 01/15/2024 drawing"},
+	}
+	for _, tc := range testCases {
+		popularity, reasons = model.TicketPopularity(game, tc.numbers, past)
+		s.InDelta(tc.popularity, popularity, 1e-9, "Test case: %s", tc.name)
+		if tc.reason != "" {
+			s.Contains(reasons, tc.reason, "Test case: %s", tc.name)
+		}
+	}
+
+	model, err := ReadPopularityModel(strings.NewReader(`{"sequence_weight": 10, "slip_columns": 8}`))
+	s.Require().NoError(err)
+	s.InDelta(10, model.SequenceWeight, 0)
+	s.Equal(8, model.SlipColumns)
+	s.InDelta(4, model.PastWinnerWeight, 0) // Weights the file leaves out keep their defaults
+	_, err = ReadPopularityModel(strings.NewReader(`{"sequence_weigth": 10}`))
+	s.Require().ErrorIs(err, ErrInvalidPopularityModel)
+	_, err = ReadPopularityModel(strings.NewReader(`{"lucky_weight": -1}`))
+	s.Require().ErrorIs(err, ErrInvalidPopularityModel)
+	_, err = ReadPopularityModel(strings.NewReader(`{"slip_columns": 0}`))
+	s.Require().ErrorIs(err, ErrInvalidPopularityModel)
+}
+
+// TestContrarianStrategy tests that contrarian sets avoid popular numbers and patterns
+func (s *AnalyzerTestSuite) TestContrarianStrategy() {
+	ctx := context.Background()
+	s.analyzer.config.Strategies = []string{"contrarian"}
+	contrarian, err := s.analyzer.Strategies().Lookup("contrarian")
+	s.Require().NoError(err)
+
+	// Numbers past the calendar that were not drawn in the recent window are not popular at all
+	for _, sn := range contrarian.ScoreMain(s.analyzer) {
+		s.Require().NotEmpty(sn.Factors)
+		s.True(strings.HasPrefix(sn.Factors[0], "Popularity-"), "Factors %v", sn.Factors)
+		unpopular := sn.Number > calendarDays && s.analyzer.mainNumbers[sn.Number].RecentFrequency == 0
+		s.Equal(unpopular, sn.Score == 0, "Number %d", sn.Number)
+	}
+	for _, sn := range contrarian.ScoreBonus(s.analyzer) {
+		s.Equal(sn.Number > calendarMonths && sn.Number != 15, sn.Score == -1, "Ball %d", sn.Number) // 15 was drawn recently
+	}
+
+	// The least popular numbers, 32, 36, 37, 39 and 40, lie along a slip row; the ticket score steers away
+	sets, err := s.analyzer.GenerateRecommendations(ctx, 3)
+	s.Require().NoError(err)
+	model := DefaultPopularityModel()
+	for _, set := range sets {
+		s.Greater(set.Numbers[0], calendarDays, "Set %v", set.Numbers)
+		popularity, reasons := model.TicketPopularity(s.analyzer.spec(), set.Numbers, s.analyzer.pastDrawingIndex())
+		s.Zero(popularity, "Set %v has %v", set.Numbers, reasons)
+		s.NotContains(set.Explanation, "still has")
+		s.NotEqual(15, set.LuckyBall)
+		s.Greater(set.LuckyBall, calendarMonths)
+	}
+
+	// A model that only cares about sequences still avoids them
+	s.analyzer.config.Popularity = &PopularityModel{SequenceWeight: 1, SlipColumns: 10}
+	sets, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().NoError(err)
+	run, _ := longestArithmeticRun(sets[0].Numbers)
+	s.Less(run, 4)
+
+	s.analyzer.config.Popularity = &PopularityModel{}
+	_, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().ErrorIs(err, ErrInvalidPopularityModel)
+}
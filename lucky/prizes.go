@@ -0,0 +1,133 @@
+package lucky
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoPrizeTable indicates a game whose prize tiers are not known
+var ErrNoPrizeTable = errors.New("no prize table for game")
+
+// PrizeTier is one winning combination of a game and what it pays
+type PrizeTier struct {
+	MainMatches int     `json:"main_matches"`
+	BonusMatch  bool    `json:"bonus_match"`
+	Name        string  `json:"name"`       // e.g. "5+LB"
+	Prize       string  `json:"prize"`      // Advertised prize, e.g. "$1,000/day for life"
+	CashValue   float64 `json:"cash_value"` // Nominal value in dollars; lifetime prizes use the lump-sum cash option
+	ForLife     bool    `json:"for_life"`   // Paid as a lifetime annuity
+}
+
+// PrizeTable lists the prize tiers of a game, best first
+type PrizeTable struct {
+	Game        string      `json:"game"`
+	TicketPrice float64     `json:"ticket_price"`
+	Tiers       []PrizeTier `json:"tiers"`
+}
+
+// Ticket is a set of numbers played in one drawing
+type Ticket struct {
+	Numbers   []int `json:"numbers"`
+	LuckyBall int   `json:"lucky_ball"`
+}
+
+// TicketResult is how a ticket fared against a drawing
+type TicketResult struct {
+	MainMatches int        `json:"main_matches"`
+	BonusMatch  bool       `json:"bonus_match"`
+	Tier        *PrizeTier `json:"tier,omitempty"` // Nil when the ticket won nothing
+	CashValue   float64    `json:"cash_value"`
+}
+
+// luckyForLifePrizes returns the Lucky for Life prize tiers for a $2 ticket
+func luckyForLifePrizes() *PrizeTable {
+	return &PrizeTable{
+		Game:        GameLuckyForLife,
+		TicketPrice: 2,
+		Tiers: []PrizeTier{
+			{MainMatches: 5, BonusMatch: true, Name: "5+LB", Prize: "$1,000/day for life", CashValue: 5_750_000, ForLife: true},
+			{MainMatches: 5, Name: "5+0", Prize: "$25,000/year for life", CashValue: 390_000, ForLife: true},
+			{MainMatches: 4, BonusMatch: true, Name: "4+LB", Prize: "$5,000", CashValue: 5000},
+			{MainMatches: 4, Name: "4+0", Prize: "$200", CashValue: 200},
+			{MainMatches: 3, BonusMatch: true, Name: "3+LB", Prize: "$150", CashValue: 150},
+			{MainMatches: 3, Name: "3+0", Prize: "$20", CashValue: 20},
+			{MainMatches: 2, BonusMatch: true, Name: "2+LB", Prize: "$25", CashValue: 25},
+			{MainMatches: 2, Name: "2+0", Prize: "$3", CashValue: 3},
+			{MainMatches: 1, BonusMatch: true, Name: "1+LB", Prize: "$6", CashValue: 6},
+			{MainMatches: 0, BonusMatch: true, Name: "0+LB", Prize: "$4", CashValue: 4},
+		},
+	}
+}
+
+// PrizeTableFor returns the prize table of a game key
+func PrizeTableFor(gameKey string) (*PrizeTable, error) {
+	spec, err := LookupGameSpec(gameKey)
+	if err != nil {
+		return nil, err
+	}
+	switch spec.Key {
+	case GameLuckyForLife:
+		return luckyForLifePrizes(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrNoPrizeTable, spec.Key)
+	}
+}
+
+// ExpectedValue returns the average cash value one ticket wins in game
+func (p *PrizeTable) ExpectedValue(game *GameSpec) float64 {
+	total := 0.0
+	for _, tier := range p.Tiers {
+		total += tier.Probability(game) * tier.CashValue
+	}
+	return total
+}
+
+// PrizeTable returns the prize table of the analyzed game
+func (a *Analyzer) PrizeTable() (*PrizeTable, error) {
+	return PrizeTableFor(a.spec().Key)
+}
+
+// Ticket returns the recommended set as a playable ticket
+func (r RecommendedSet) Ticket() Ticket {
+	return Ticket{Numbers: r.Numbers, LuckyBall: r.LuckyBall}
+}
+
+// Tier returns the prize tier for a match count, or nil when it pays nothing
+func (p *PrizeTable) Tier(mainMatches int, bonusMatch bool) *PrizeTier {
+	for i := range p.Tiers {
+		if p.Tiers[i].MainMatches == mainMatches && p.Tiers[i].BonusMatch == bonusMatch {
+			tier := p.Tiers[i]
+			return &tier
+		}
+	}
+	return nil
+}
+
+// Evaluate scores a ticket against a drawing and returns the prize tier it won, if any
+func (p *PrizeTable) Evaluate(ticket Ticket, drawing Drawing) TicketResult {
+	result := TicketResult{
+		MainMatches: countMatches(ticket.Numbers, drawing.Numbers),
+		BonusMatch:  ticket.LuckyBall != 0 && ticket.LuckyBall == drawing.LuckyBall,
+	}
+	result.Tier = p.Tier(result.MainMatches, result.BonusMatch)
+	if result.Tier != nil {
+		result.CashValue = result.Tier.CashValue
+	}
+	return result
+}
+
+// Probability returns the chance that one ticket wins exactly the given tier of game
+func (t PrizeTier) Probability(game *GameSpec) float64 {
+	main := math.Exp(logChoose(game.MainPicks, t.MainMatches) +
+		logChoose(game.MainPoolSize-game.MainPicks, game.MainPicks-t.MainMatches) -
+		logChoose(game.MainPoolSize, game.MainPicks))
+	if !game.HasBonus() {
+		return main
+	}
+	bonus := 1 / float64(game.BonusPoolSize)
+	if !t.BonusMatch {
+		bonus = 1 - bonus
+	}
+	return main * bonus
+}
@@ -0,0 +1,83 @@
+package lucky
+
+import (
+	"math"
+	"time"
+)
+
+// TestPrizeTierOdds tests the Lucky for Life tiers against the published odds
+func (s *AnalyzerTestSuite) TestPrizeTierOdds() {
+	prizes, err := PrizeTableFor(GameLuckyForLife)
+	s.Require().NoError(err)
+	s.InDelta(2.0, prizes.TicketPrice, 0)
+
+	published := map[string]float64{
+		"5+LB": 30_821_472, "5+0": 1_813_028, "4+LB": 143_356, "4+0": 8_433, "3+LB": 3_413,
+		"3+0": 201, "2+LB": 250, "2+0": 15, "1+LB": 50, "0+LB": 32,
+	}
+	game := luckyForLifeSpec()
+	s.Require().Len(prizes.Tiers, len(published))
+	for _, tier := range prizes.Tiers {
+		odds, ok := published[tier.Name]
+		s.Require().True(ok, tier.Name)
+		s.InDelta(odds, math.Round(1/tier.Probability(game)), 0, tier.Name)
+	}
+
+	// Best tier first; the top two pay for life
+	s.Equal("5+LB", prizes.Tiers[0].Name)
+	s.True(prizes.Tiers[0].ForLife)
+	s.True(prizes.Tiers[1].ForLife)
+	s.False(prizes.Tiers[2].ForLife)
+
+	ev := prizes.ExpectedValue(game)
+	s.Greater(ev, 0.0)
+	s.Less(ev, prizes.TicketPrice)
+}
+
+// TestEvaluateTicket tests scoring tickets and recommended sets against a drawing
+func (s *AnalyzerTestSuite) TestEvaluateTicket() {
+	prizes := luckyForLifePrizes()
+	drawing := Drawing{Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7}
+
+	testCases := []struct {
+		ticket Ticket
+		tier   string
+		cash   float64
+	}{
+		{Ticket{Numbers: []int{45, 34, 23, 12, 5}, LuckyBall: 7}, "5+LB", 5_750_000},
+		{Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 8}, "5+0", 390_000},
+		{Ticket{Numbers: []int{5, 12, 23, 1, 2}, LuckyBall: 7}, "3+LB", 150},
+		{Ticket{Numbers: []int{5, 12, 1, 2, 3}, LuckyBall: 1}, "2+0", 3},
+		{Ticket{Numbers: []int{1, 2, 3, 4, 6}, LuckyBall: 7}, "0+LB", 4},
+	}
+	for _, tc := range testCases {
+		result := prizes.Evaluate(tc.ticket, drawing)
+		s.Require().NotNil(result.Tier, tc.tier)
+		s.Equal(tc.tier, result.Tier.Name)
+		s.InDelta(tc.cash, result.CashValue, 0, tc.tier)
+	}
+
+	// One main number without the Lucky Ball pays nothing
+	result := prizes.Evaluate(Ticket{Numbers: []int{5, 1, 2, 3, 4}, LuckyBall: 1}, drawing)
+	s.Equal(1, result.MainMatches)
+	s.False(result.BonusMatch)
+	s.Nil(result.Tier)
+	s.Zero(result.CashValue)
+
+	// A recommended set plays as its ticket
+	set := RecommendedSet{Numbers: []int{5, 12, 23, 34, 1}, LuckyBall: 7, Strategy: "hot"}
+	s.Equal("4+LB", prizes.Evaluate(set.Ticket(), drawing).Tier.Name)
+}
+
+// TestPrizeTableFor tests looking up prize tables by game
+func (s *AnalyzerTestSuite) TestPrizeTableFor() {
+	prizes, err := s.analyzer.PrizeTable()
+	s.Require().NoError(err)
+	s.Equal(GameLuckyForLife, prizes.Game)
+
+	_, err = PrizeTableFor(GamePowerball)
+	s.Require().ErrorIs(err, ErrNoPrizeTable)
+
+	_, err = PrizeTableFor("keno")
+	s.Require().ErrorIs(err, ErrUnknownGame)
+}
@@ -0,0 +1,560 @@
+package lucky
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Console report rules
+	ruleHeavy  = "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+	ruleDouble = "═══════════════════════════════════════════════════════════"
+	ruleCosmic = "═══════════════════════════════════════════════════════════════════"
+
+	// Console report section sizes
+	simpleNumberCount         = 5
+	simpleRecommendationCount = 3
+	cosmicRecommendationCount = 3
+	topOddEvenCount           = 3
+)
+
+// Renderer writes a Report to an output stream
+type Renderer interface {
+	Render(w io.Writer, r *Report) error
+}
+
+// DetailedRenderer renders the comprehensive console report
+type DetailedRenderer struct{}
+
+// SimpleRenderer renders the short console summary
+type SimpleRenderer struct{}
+
+// StatisticalRenderer renders the statistical console report
+type StatisticalRenderer struct{}
+
+// CosmicRenderer renders the cosmic correlation console report
+type CosmicRenderer struct{}
+
+// ConsoleRenderer returns the console renderer for an output mode, defaulting to the detailed report
+func ConsoleRenderer(mode string) Renderer {
+	switch mode {
+	case OutputModeSimple:
+		return SimpleRenderer{}
+	case OutputModeStatistical:
+		return StatisticalRenderer{}
+	case OutputModeCosmic:
+		return CosmicRenderer{}
+	default:
+		return DetailedRenderer{}
+	}
+}
+
+// consoleWriter formats text to a writer and remembers the first write error
+type consoleWriter struct {
+	w   io.Writer
+	err error
+}
+
+// printf writes formatted text unless an earlier write failed
+func (c *consoleWriter) printf(format string, args ...interface{}) {
+	if c.err == nil {
+		_, c.err = fmt.Fprintf(c.w, format, args...)
+	}
+}
+
+// println writes its arguments followed by a newline unless an earlier write failed
+func (c *consoleWriter) println(args ...interface{}) {
+	if c.err == nil {
+		_, c.err = fmt.Fprintln(c.w, args...)
+	}
+}
+
+// section writes a section title between heavy rules
+func (c *consoleWriter) section(title string) {
+	c.println("\n" + ruleHeavy)
+	c.println(title)
+	c.println(ruleHeavy)
+}
+
+// numbers writes a ticket as zero-padded, dash-separated numbers (e.g. 05-12-23)
+func (c *consoleWriter) numbers(numbers []int) {
+	parts := make([]string, len(numbers))
+	for i, num := range numbers {
+		parts[i] = fmt.Sprintf("%02d", num)
+	}
+	c.printf("%s", strings.Join(parts, "-"))
+}
+
+// dateRange formats the first and last drawing dates of a report
+func dateRange(r *Report) string {
+	return fmt.Sprintf("%s to %s", r.FirstDrawing.Format("01/02/2006"), r.LastDrawing.Format("01/02/2006"))
+}
+
+// firstN returns at most n elements of a slice
+func firstN[T any](items []T, n int) []T {
+	if n < len(items) {
+		return items[:n]
+	}
+	return items
+}
+
+// Render writes the comprehensive analysis report
+func (DetailedRenderer) Render(w io.Writer, r *Report) error {
+	c := &consoleWriter{w: w}
+	game := r.Game
+	c.println("╔══════════════════════════════════════════════════════════╗")
+	c.printf("║        %-50s║\n", strings.ToUpper(game.Name)+" LOTTERY ANALYZER")
+	c.println("╚══════════════════════════════════════════════════════════╝")
+
+	// Metadata
+	c.printf("\nTotal Drawings Analyzed: %d\n", r.TotalDrawings)
+	c.printf("Date Range: %s\n", dateRange(r))
+	c.printf("Randomness Score: %.1f%% (100%% = perfectly random)\n", r.RandomnessScore)
+	c.printf("Chi-Square (main): %.2f, df %d, p = %.4f\n", r.MainChiSquare.Statistic, r.MainChiSquare.DegreesOfFreedom, r.MainChiSquare.PValue)
+	if bonus := r.BonusChiSquare; bonus != nil {
+		c.printf("Chi-Square (%s): %.2f, df %d, p = %.4f\n", strings.ToLower(game.BonusName), bonus.Statistic, bonus.DegreesOfFreedom, bonus.PValue)
+	}
+
+	// Frequency Analysis
+	c.section("                    FREQUENCY ANALYSIS")
+
+	c.printf("\nHOT NUMBERS (Last %d Drawings):\n", r.RecentWindow)
+	for i, info := range r.HotNumbers {
+		c.printf("  %2d. Number %2d: %d times (%.1f%%) | Total: %d\n",
+			i+1, info.Number, info.RecentFrequency,
+			float64(info.RecentFrequency)/float64(r.RecentWindow)*100,
+			info.TotalFrequency)
+	}
+
+	c.println("\nMOST FREQUENT (All Time):")
+	for i, info := range r.FrequentNumbers {
+		deviation := float64(info.TotalFrequency) - info.ExpectedFrequency
+		c.printf("  %2d. Number %2d: %d times (%.1f%% deviation from expected)\n",
+			i+1, info.Number, info.TotalFrequency,
+			(deviation/info.ExpectedFrequency)*100)
+	}
+
+	// Overdue Analysis
+	c.section("                    OVERDUE ANALYSIS")
+
+	c.println("\nMOST OVERDUE NUMBERS:")
+	for i, info := range r.OverdueNumbers {
+		overdueRatio := float64(info.CurrentGap) / info.AverageGap
+		daysAgo := int(r.LastDrawing.Sub(info.LastDrawnDate).Hours() / 24)
+		c.printf("  %2d. Number %2d: Not drawn for %d drawings (%.1fx overdue) | %d days ago\n",
+			i+1, info.Number, info.CurrentGap, overdueRatio, daysAgo)
+	}
+
+	// Pattern Analysis
+	c.section("                    PATTERN ANALYSIS")
+
+	c.println("\nODD/EVEN DISTRIBUTION:")
+	oddEvenFit := r.PatternFit(PatternFamilyOddEven)
+	for _, pattern := range firstN(r.OddEvenPatterns, topOddEvenCount) {
+		percentage := float64(pattern.Count) / float64(r.TotalDrawings) * 100
+		c.printf("  %s: %d times (%.1f%%", pattern.Pattern, pattern.Count, percentage)
+		if bin := oddEvenFit.bin(pattern.Pattern); bin != nil {
+			c.printf(", %.1f%% expected", bin.Probability*100)
+		}
+		c.println(")")
+	}
+
+	consecutivePercent := float64(r.ConsecutiveCount) / float64(r.TotalDrawings) * 100
+	c.printf("\nConsecutive Numbers: %d drawings (%.1f%%)\n", r.ConsecutiveCount, consecutivePercent)
+
+	// Combination patterns
+	c.section("                 COMBINATION PATTERNS")
+
+	matrix := r.PairMatrix
+	c.printf("\nTOP PAIRS (a fair draw expects each pair %.1f times):\n", matrix.Expected)
+	for _, pair := range r.TopPairs {
+		c.printf("  %s: %d times", pair.Key, pair.Frequency)
+		if unusual := matrix.unusual(pair.Key); unusual != nil {
+			c.printf(" - unusual (adjusted p = %.4f)\n", unusual.AdjustedPValues.BenjaminiHochberg)
+		} else {
+			c.println(" - within chance")
+		}
+	}
+	if tests := matrix.Comparisons; tests.Tests > 0 {
+		c.printf("Unusual pairs: %d of %d after false discovery rate control at %.0f%% confidence\n",
+			tests.BenjaminiHochberg, tests.Tests, (1-tests.Alpha)*100)
+	}
+
+	// Recommendations
+	c.section("                    RECOMMENDATIONS")
+
+	c.println("\nRECOMMENDED NUMBER SETS:")
+	for i, rec := range r.Recommendations {
+		c.printf("\nSet %d - %s Strategy:\n", i+1, rec.Strategy)
+		c.printf("  Numbers: ")
+		c.numbers(rec.Numbers)
+		if game.HasBonus() {
+			c.printf("  %s: %d", game.BonusName, rec.LuckyBall)
+		}
+		c.println()
+		c.printf("  %s\n", rec.Explanation)
+		if rec.Value != nil {
+			c.printf("  %s\n", rec.Value)
+		}
+	}
+	if len(r.Recommendations) > 0 && r.Recommendations[0].Value != nil {
+		c.println("\nEvery set has the same odds and expected return; no strategy changes them.")
+	}
+
+	// Add cosmic correlation report
+	writeCosmicReport(c, r.Correlations, r.MultipleComparisons, r.CosmicConditions)
+
+	c.section("                     DISCLAIMER")
+	c.println("These recommendations are based on historical pattern analysis.")
+	c.printf("The randomness score of %.1f%% indicates the drawings are ", r.RandomnessScore)
+	switch {
+	case r.RandomnessScore > 90:
+		c.println("highly random.")
+	case r.RandomnessScore > 70:
+		c.println("mostly random with minor deviations.")
+	default:
+		c.println("showing some non-random patterns.")
+	}
+	c.println("Lottery drawings are designed to be random events.")
+	c.println("Past results do not influence future outcomes.")
+	c.println("Play responsibly!")
+
+	return c.err
+}
+
+// Render writes the short analysis summary
+func (SimpleRenderer) Render(w io.Writer, r *Report) error {
+	c := &consoleWriter{w: w}
+	c.println("LOTTERY ANALYSIS SUMMARY")
+	c.println("========================")
+
+	c.printf("Drawings analyzed: %d\n", r.TotalDrawings)
+	c.printf("Randomness: %.1f%%\n\n", r.RandomnessScore)
+
+	c.println("TOP 5 HOT NUMBERS:")
+	for _, info := range firstN(r.HotNumbers, simpleNumberCount) {
+		c.printf("  %2d (recent: %d times)\n", info.Number, info.RecentFrequency)
+	}
+
+	c.println("\nTOP 5 OVERDUE:")
+	for _, info := range firstN(r.OverdueNumbers, simpleNumberCount) {
+		c.printf("  %2d (gap: %d drawings)\n", info.Number, info.CurrentGap)
+	}
+
+	c.println("\nQUICK PICKS:")
+	for i, rec := range firstN(r.Recommendations, simpleRecommendationCount) {
+		c.printf("  Set %d: ", i+1)
+		c.numbers(rec.Numbers)
+		if r.Game.HasBonus() {
+			c.printf(" LB:%d", rec.LuckyBall)
+		}
+		c.println()
+	}
+
+	// Add cosmic pick
+	c.println("\n🌌 COSMIC PICK:")
+	c.printf("  ")
+	c.numbers(r.CosmicPick)
+	if r.Game.HasBonus() {
+		c.printf(" LB:%d", r.CosmicLuckyBall)
+	}
+	c.println()
+
+	return c.err
+}
+
+// Render writes the statistical analysis report
+func (StatisticalRenderer) Render(w io.Writer, r *Report) error {
+	c := &consoleWriter{w: w}
+	dist := r.Distribution
+	c.println("STATISTICAL ANALYSIS REPORT")
+	c.println("===========================")
+
+	// Chi-square analysis
+	c.printf("\nChi-Square Test for Randomness (%.0f%% confidence):\n", r.MainChiSquare.ConfidenceLevel*100)
+	writeChiSquareTest(c, "Main numbers", r.MainChiSquare)
+	if r.BonusChiSquare != nil {
+		writeChiSquareTest(c, r.Game.BonusName, *r.BonusChiSquare)
+	}
+	c.printf("  Combined Chi-Square Value: %.4f\n", r.ChiSquare)
+	c.printf("  Randomness Score: %.2f%%\n", r.RandomnessScore)
+
+	// Distribution analysis
+	c.println("\nFrequency Distribution Analysis:")
+	c.printf("  Expected frequency per number: %.2f\n", dist.ExpectedFrequency)
+	c.printf("  Standard deviation: %.2f\n", dist.StandardDeviation)
+	c.printf("  Coefficient of variation: %.2f%%\n", dist.CoefficientOfVariation)
+	c.printf("  Numbers outside 2σ: %d (%.1f%%)\n", dist.OutsideTwoSigma, float64(dist.OutsideTwoSigma)/float64(r.Game.MainPoolSize)*100)
+
+	// Gap analysis
+	c.println("\nGap Analysis Statistics:")
+	c.printf("  Average gap length: %.2f drawings\n", dist.AverageGap)
+	c.printf("  Minimum gap: %d drawings\n", dist.MinGap)
+	c.printf("  Maximum gap: %d drawings\n", dist.MaxGap)
+
+	// Patterns against their exact distributions
+	c.println("\nPattern Distributions vs. a Fair Draw:")
+	for _, fit := range r.PatternFits {
+		c.printf("\n  %s:\n", patternFamilyTitle(fit.Family))
+		c.printf("    %-10s %9s %11s\n", "Pattern", "Observed", "Expected")
+		for _, bin := range fit.Bins {
+			if bin.Observed > 0 || bin.Expected >= 0.05 {
+				c.printf("    %-10s %9d %11.1f\n", bin.Pattern, bin.Observed, bin.Expected)
+			}
+		}
+		writeChiSquareTest(c, "Goodness of fit", fit.Test)
+	}
+
+	writePairHeatmap(c, r.PairMatrix)
+	return c.err
+}
+
+// writePairHeatmap writes how often every pair of main numbers was drawn together, shaded by the ratio of
+// observed to expected count, followed by the pairs that stay significant after correction
+func writePairHeatmap(c *consoleWriter, m PairMatrix) {
+	if len(m.Counts) == 0 {
+		return
+	}
+	c.println("\nPair Co-occurrence Heatmap (observed ÷ expected count):")
+	var tens, units strings.Builder
+	for i := range m.Counts {
+		num := m.MinNumber + i
+		if num%10 == 0 {
+			tens.WriteString(strconv.Itoa(num / 10 % 10))
+		} else {
+			tens.WriteByte(' ')
+		}
+		units.WriteString(strconv.Itoa(num % 10))
+	}
+	c.printf("      %s\n      %s\n", tens.String(), units.String())
+
+	unusual := make(map[string]bool, len(m.Unusual))
+	for _, pair := range m.Unusual {
+		unusual[pair.Key] = true
+	}
+	for i, row := range m.Counts {
+		var cells strings.Builder
+		for j, count := range row {
+			low, high := min(i, j), max(i, j)
+			key := fmt.Sprintf("%d-%d", m.MinNumber+low, m.MinNumber+high)
+			cells.WriteString(heatmapCell(count, m.expected(i, j), unusual[key]))
+		}
+		c.printf("  %3d %s\n", m.MinNumber+i, cells.String())
+	}
+	c.println("  · under 0.5   ░ under 1   ▒ under 1.5   ▓ under 2   █ 2 or more   + / - significantly more / fewer")
+
+	tests := m.Comparisons
+	c.printf("\n  Pairs tested: %d (exact binomial, α = %.2f)\n", tests.Tests, tests.Alpha)
+	c.printf("  Significant before correction: %d (about %.1f expected by chance alone)\n",
+		tests.Unadjusted, tests.ExpectedFalsePositives())
+	c.printf("  Survive correction: Bonferroni %d | Holm %d | Benjamini-Hochberg %d\n",
+		tests.Bonferroni, tests.Holm, tests.BenjaminiHochberg)
+	for _, pair := range m.Unusual {
+		c.printf("    %s: %d times (%.1f expected), adjusted p = %.4f\n",
+			pair.Key, pair.Observed, pair.Expected, pair.AdjustedPValues.BenjaminiHochberg)
+	}
+}
+
+// heatmapCell returns the heatmap shade of a pair count, or a sign for a significant pair.
+// Pairs a game cannot draw, such as a number with itself, are left blank.
+func heatmapCell(count int, expected float64, significant bool) string {
+	switch {
+	case expected <= 0:
+		return " "
+	case significant && float64(count) > expected:
+		return "+"
+	case significant:
+		return "-"
+	}
+	ratio := float64(count) / expected
+	switch {
+	case ratio < 0.5:
+		return "·"
+	case ratio < 1:
+		return "░"
+	case ratio < 1.5:
+		return "▒"
+	case ratio < 2:
+		return "▓"
+	default:
+		return "█"
+	}
+}
+
+// writeChiSquareTest writes one chi-square goodness-of-fit result with its verdict
+func writeChiSquareTest(c *consoleWriter, label string, test ChiSquareTest) {
+	c.printf("  %s:\n", label)
+	c.printf("    Chi-Square: %.4f (df = %d, critical value %.4f)\n", test.Statistic, test.DegreesOfFreedom, test.CriticalValue)
+	c.printf("    p-value: %.4f", test.PValue)
+	if test.RejectsUniform {
+		c.println(" - frequencies deviate from a fair draw")
+	} else {
+		c.println(" - consistent with a fair draw")
+	}
+}
+
+// Render writes the cosmic correlation report
+func (CosmicRenderer) Render(w io.Writer, r *Report) error {
+	c := &consoleWriter{w: w}
+	game := r.Game
+	c.println("╔══════════════════════════════════════════════════════════╗")
+	c.println("║        COSMIC LOTTERY CORRELATION ANALYZER               ║")
+	c.println("╚══════════════════════════════════════════════════════════╝")
+
+	c.printf("\nTotal Drawings Analyzed: %d\n", r.TotalDrawings)
+	c.printf("Date Range: %s\n", dateRange(r))
+
+	// Show cosmic correlation report
+	writeCosmicReport(c, r.Correlations, r.MultipleComparisons, r.CosmicConditions)
+
+	// Generate cosmic-influenced recommendations
+	c.println("\n" + ruleDouble)
+	c.println("              🎯 COSMIC-INFLUENCED PREDICTIONS")
+	c.println(ruleDouble)
+
+	c.println("\nBased on current cosmic conditions:")
+	c.printf("\n🌟 Cosmic Selection: ")
+	c.numbers(r.CosmicPick)
+	if game.HasBonus() {
+		c.printf("  %s: %d", game.BonusName, r.CosmicLuckyBall)
+	}
+	c.println()
+
+	c.println("\n📊 Combined Statistical + Cosmic Picks:")
+	for i, rec := range firstN(r.Recommendations, cosmicRecommendationCount) {
+		c.printf("\nSet %d - %s + Cosmic Alignment:\n", i+1, rec.Strategy)
+		c.printf("  Numbers: ")
+		c.numbers(rec.Numbers)
+		if game.HasBonus() {
+			c.printf("  %s: %d", game.BonusName, rec.LuckyBall)
+		}
+		c.println()
+		if rec.Value != nil {
+			c.printf("  %s (cosmic alignment changes none of it)\n", rec.Value)
+		}
+	}
+
+	c.println("\n" + ruleDouble)
+	c.println("                      COSMIC WISDOM")
+	c.println(ruleDouble)
+	c.println("\n🌙 'As above, so below' - but lottery balls don't look up!")
+	c.println("☀️  The sun has witnessed every drawing, yet keeps its secrets.")
+	c.println("✨ Remember: The universe is under no obligation to make sense.")
+	c.println("🎲 ...or to make you wealthy!")
+
+	return c.err
+}
+
+// writeCosmicReport writes the cosmic correlation report grouped by factor
+func writeCosmicReport(c *consoleWriter, results []CorrelationResult, comparisons MultipleComparisons, conditions CosmicConditions) {
+	c.printf("\n")
+	c.println(ruleCosmic)
+	c.println("                    🌌 COSMIC CORRELATION ANALYSIS 🌌                ")
+	c.printf("%s\n\n", ruleCosmic)
+
+	c.println("⚠️  DISCLAIMER: This analysis explores statistical correlations")
+	c.println("between cosmic phenomena and lottery outcomes for entertainment")
+	c.printf("and educational purposes only. Lottery drawings are random events.\n\n")
+
+	// Group correlations by factor
+	factorGroups := make(map[string][]CorrelationResult)
+	for _, result := range results {
+		factorGroups[result.Factor] = append(factorGroups[result.Factor], result)
+	}
+
+	sections := []struct {
+		factor, title, rule string
+	}{
+		{"Moon Phase", "🌙 LUNAR CORRELATIONS", "─────────────────────"},
+		{"Solar Activity", "☀️  SOLAR ACTIVITY CORRELATIONS", "─────────────────────────────"},
+		{"Weather", "🌤️  WEATHER CORRELATIONS", "───────────────────────"},
+		{"Temporal", "📅 TEMPORAL PATTERNS", "──────────────────"},
+		{"Planetary", "🪐 PLANETARY INFLUENCES", "─────────────────────"},
+	}
+	for _, section := range sections {
+		group, exists := factorGroups[section.factor]
+		if !exists {
+			continue
+		}
+		c.println(section.title)
+		c.println(section.rule)
+		for _, result := range group {
+			c.printf("%s", formatCorrelationResult(result, comparisons.Alpha))
+		}
+		c.println()
+	}
+
+	writeMultipleComparisons(c, comparisons)
+	writeCurrentConditions(c, conditions)
+	writeCosmicFunFacts(c)
+}
+
+// writeMultipleComparisons writes how many correlation findings survive correction for the number of tests
+func writeMultipleComparisons(c *consoleWriter, m MultipleComparisons) {
+	if m.Tests == 0 {
+		return
+	}
+	c.println("🧮 MULTIPLE COMPARISONS")
+	c.println("──────────────────────")
+	c.printf("Tests run: %d (α = %.2f)\n", m.Tests, m.Alpha)
+	c.printf("Significant before correction: %d\n", m.Unadjusted)
+	c.printf("Survive correction: Bonferroni %d | Holm %d | Benjamini-Hochberg %d\n",
+		m.Bonferroni, m.Holm, m.BenjaminiHochberg)
+	c.printf("With %d tests, about %.1f \"significant\" results are expected by chance alone.\n",
+		m.Tests, m.ExpectedFalsePositives())
+	c.println()
+}
+
+// writeCurrentConditions writes the cosmic conditions and the matching suggestion
+func writeCurrentConditions(c *consoleWriter, conditions CosmicConditions) {
+	c.println("🔮 CURRENT COSMIC CONDITIONS")
+	c.println("──────────────────────────")
+	c.printf("Date: %s\n", conditions.Date.Format("January 2, 2006"))
+	c.printf("Moon Phase: %s (%.0f%% illuminated)\n", conditions.MoonPhaseName, conditions.MoonIllumination*100)
+	c.printf("Zodiac Sign: %s\n", conditions.ZodiacSign)
+	c.printf("Day of Week: %s\n", conditions.DayOfWeek)
+	c.println()
+
+	// Cosmic recommendation based on current conditions
+	c.println("🎯 TODAY'S COSMIC SUGGESTION:")
+
+	switch conditions.MoonPhaseName {
+	case "Full Moon":
+		c.println("  The full moon historically shows a 2.3% increase in high numbers.")
+		c.println("  Consider including numbers above 30 in your selection.")
+	case "New Moon":
+		c.println("  New moon periods show balanced number distribution.")
+		c.println("  A mix of high and low numbers may be favorable.")
+	default:
+		c.println("  Current lunar phase shows no significant historical patterns.")
+		c.println("  Standard statistical selection recommended.")
+	}
+
+	c.println()
+}
+
+// writeCosmicFunFacts writes entertaining cosmic facts
+func writeCosmicFunFacts(c *consoleWriter) {
+	facts := []string{
+		"🌟 Numbers 7 and 13 show 0.8% higher frequency during meteor showers!",
+		"🌊 High tide correlates with a 1.2% increase in water-sign numbers (4, 8, 12)!",
+		"⚡ Geomagnetic storms coincide with 0.5% more consecutive number pairs!",
+		"🌙 Lunar eclipses show no correlation - the moon keeps its lottery secrets!",
+		"☄️  Halley's Comet years show identical number distributions (sorry, no cosmic luck)!",
+	}
+
+	c.println("✨ COSMIC CURIOSITIES")
+	c.println("───────────────────")
+
+	// Select a few facts
+	for _, fact := range firstN(facts, 3) {
+		c.println(fact)
+	}
+
+	c.println("\n📊 STATISTICAL REALITY CHECK:")
+	c.println("All correlations shown are within normal random variation.")
+	c.println("These patterns are entertaining coincidences, not predictive tools.")
+	c.println("Remember: Every drawing has exactly the same odds!")
+}
@@ -0,0 +1,174 @@
+package lucky
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrUnknownOutputFormat indicates an output format with no renderer
+var ErrUnknownOutputFormat = errors.New("unknown output format")
+
+// Output formats
+const (
+	OutputFormatText   = "text"
+	OutputFormatJSON   = "json"
+	OutputFormatNDJSON = "ndjson"
+)
+
+// NDJSON record types
+const (
+	RecordTypeSummary          = "summary"
+	RecordTypeHotNumber        = "hot_number"
+	RecordTypeFrequentNumber   = "frequent_number"
+	RecordTypeOverdueNumber    = "overdue_number"
+	RecordTypeOddEvenPattern   = "odd_even_pattern"
+	RecordTypePatternFit       = "pattern_fit"
+	RecordTypePair             = "pair"
+	RecordTypePairMatrix       = "pair_matrix"
+	RecordTypeRecommendation   = "recommendation"
+	RecordTypeCosmicPick       = "cosmic_pick"
+	RecordTypeCorrelation      = "correlation"
+	RecordTypeBacktestSummary  = "backtest_summary"
+	RecordTypeBacktestStrategy = "backtest_strategy"
+	RecordTypeTicketCheck      = "ticket_check"
+	RecordTypeLedgerEntry      = "ledger_entry"
+	RecordTypeLedgerPosition   = "ledger_position"
+	RecordTypeWheelSummary     = "wheel_summary"
+	RecordTypeWheelTicket      = "wheel_ticket"
+	RecordTypeExpectedValue    = "expected_value"
+	RecordTypeBankrollSummary  = "bankroll_summary"
+	RecordTypeBankrollPoint    = "bankroll_point"
+)
+
+// Record is one line of NDJSON output; Data holds the value named by Type
+type Record struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// ReportSummary is the dataset overview record of an NDJSON report
+type ReportSummary struct {
+	Mode                string              `json:"mode"`
+	Game                GameSpec            `json:"game"`
+	GeneratedAt         time.Time           `json:"generated_at"`
+	TotalDrawings       int                 `json:"total_drawings"`
+	FirstDrawing        time.Time           `json:"first_drawing"`
+	LastDrawing         time.Time           `json:"last_drawing"`
+	RecentWindow        int                 `json:"recent_window"`
+	ChiSquare           float64             `json:"chi_square"`
+	MainChiSquare       ChiSquareTest       `json:"main_chi_square"`
+	BonusChiSquare      *ChiSquareTest      `json:"bonus_chi_square,omitempty"`
+	RandomnessScore     float64             `json:"randomness_score"`
+	ConsecutiveCount    int                 `json:"consecutive_count"`
+	CosmicConditions    CosmicConditions    `json:"cosmic_conditions"`
+	Distribution        DistributionStats   `json:"distribution"`
+	MultipleComparisons MultipleComparisons `json:"multiple_comparisons"`
+}
+
+// CosmicPick is the cosmic pick record of an NDJSON report
+type CosmicPick struct {
+	Numbers   []int `json:"numbers"`
+	LuckyBall int   `json:"lucky_ball,omitempty"`
+}
+
+// JSONRenderer renders the report as one indented JSON document
+type JSONRenderer struct{}
+
+// NDJSONRenderer renders the report as newline-delimited JSON records
+type NDJSONRenderer struct{}
+
+// NewRenderer returns the renderer for an output format; text uses the console renderer of mode
+func NewRenderer(mode, format string) (Renderer, error) {
+	switch format {
+	case OutputFormatText, "":
+		return ConsoleRenderer(mode), nil
+	case OutputFormatJSON:
+		return JSONRenderer{}, nil
+	case OutputFormatNDJSON:
+		return NDJSONRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOutputFormat, format)
+	}
+}
+
+// Render implements Renderer
+func (JSONRenderer) Render(w io.Writer, r *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// Render implements Renderer
+func (NDJSONRenderer) Render(w io.Writer, r *Report) error {
+	return WriteRecords(w, r.Records())
+}
+
+// WriteRecords writes records as newline-delimited JSON
+func WriteRecords(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode %s record: %w", record.Type, err)
+		}
+	}
+	return nil
+}
+
+// Records flattens the report into NDJSON records, starting with the summary
+func (r *Report) Records() []Record {
+	records := []Record{{
+		Type: RecordTypeSummary,
+		Data: ReportSummary{
+			Mode:             r.Mode,
+			Game:             r.Game,
+			GeneratedAt:      r.GeneratedAt,
+			TotalDrawings:    r.TotalDrawings,
+			FirstDrawing:     r.FirstDrawing,
+			LastDrawing:      r.LastDrawing,
+			RecentWindow:     r.RecentWindow,
+			ChiSquare:        r.ChiSquare,
+			MainChiSquare:    r.MainChiSquare,
+			BonusChiSquare:   r.BonusChiSquare,
+			RandomnessScore:  r.RandomnessScore,
+			ConsecutiveCount: r.ConsecutiveCount,
+			CosmicConditions: r.CosmicConditions,
+			Distribution:     r.Distribution,
+		},
+	}}
+
+	for _, info := range r.HotNumbers {
+		records = append(records, Record{Type: RecordTypeHotNumber, Data: info})
+	}
+	for _, info := range r.FrequentNumbers {
+		records = append(records, Record{Type: RecordTypeFrequentNumber, Data: info})
+	}
+	for _, info := range r.OverdueNumbers {
+		records = append(records, Record{Type: RecordTypeOverdueNumber, Data: info})
+	}
+	for _, pattern := range r.OddEvenPatterns {
+		records = append(records, Record{Type: RecordTypeOddEvenPattern, Data: pattern})
+	}
+	for _, fit := range r.PatternFits {
+		records = append(records, Record{Type: RecordTypePatternFit, Data: fit})
+	}
+	for _, pair := range r.TopPairs {
+		records = append(records, Record{Type: RecordTypePair, Data: pair})
+	}
+	records = append(records, Record{Type: RecordTypePairMatrix, Data: r.PairMatrix})
+	for _, rec := range r.Recommendations {
+		records = append(records, Record{Type: RecordTypeRecommendation, Data: rec})
+	}
+	if len(r.CosmicPick) > 0 {
+		records = append(records, Record{Type: RecordTypeCosmicPick, Data: CosmicPick{Numbers: r.CosmicPick, LuckyBall: r.CosmicLuckyBall}})
+	}
+	for _, result := range r.Correlations {
+		records = append(records, Record{Type: RecordTypeCorrelation, Data: result})
+	}
+	return records
+}
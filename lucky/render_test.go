@@ -0,0 +1,170 @@
+package lucky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+)
+
+// errWriter is an io.Writer that always fails
+type errWriter struct{}
+
+// Write implements io.Writer
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed") //nolint:err113 // test-only failure
+}
+
+// renderReport builds a report for analyzer and renders it with renderer
+func (s *AnalyzerTestSuite) renderReport(analyzer *Analyzer, renderer Renderer) string {
+	report, err := analyzer.BuildReport(context.Background())
+	s.Require().NoError(err)
+
+	var buf bytes.Buffer
+	s.Require().NoError(renderer.Render(&buf, report))
+	return buf.String()
+}
+
+// TestBuildReport tests the report snapshot of the analysis
+func (s *AnalyzerTestSuite) TestBuildReport() {
+	report, err := s.analyzer.BuildReport(context.Background())
+	s.Require().NoError(err)
+
+	s.Equal(OutputModeSimple, report.Mode)
+	s.Equal(GameLuckyForLife, report.Game.Key)
+	s.Equal(5, report.TotalDrawings)
+	s.Equal(3, report.RecentWindow)
+	drawings := s.analyzer.Drawings()
+	s.Equal(drawings[0].Date, report.LastDrawing)
+	s.Equal(drawings[len(drawings)-1].Date, report.FirstDrawing)
+	s.Len(report.HotNumbers, reportNumberCount)
+	s.Len(report.Recommendations, reportRecommendationCount)
+	s.Len(report.CosmicPick, 5)
+	s.True(s.analyzer.spec().ValidBonus(report.CosmicLuckyBall))
+	s.Len(report.TopPairs, reportPairCount)
+	s.NotEmpty(report.CosmicConditions.MoonPhaseName)
+
+	// Patterns and pairs are sorted most common first
+	for i := 1; i < len(report.OddEvenPatterns); i++ {
+		s.GreaterOrEqual(report.OddEvenPatterns[i-1].Count, report.OddEvenPatterns[i].Count)
+	}
+	s.Equal("23-34", report.TopPairs[0].Key)
+
+	// Expected frequency is drawings * picks / pool
+	s.InDelta(5.0*5/48, report.Distribution.ExpectedFrequency, 0.0001)
+}
+
+// TestConsoleRenderers tests that each output mode renders its own report
+func (s *AnalyzerTestSuite) TestConsoleRenderers() {
+	testCases := []struct {
+		mode     string
+		renderer Renderer
+		contains string
+	}{
+		{OutputModeDetailed, DetailedRenderer{}, "LUCKY FOR LIFE LOTTERY ANALYZER"},
+		{OutputModeSimple, SimpleRenderer{}, "QUICK PICKS:"},
+		{OutputModeStatistical, StatisticalRenderer{}, "STATISTICAL ANALYSIS REPORT"},
+		{OutputModeCosmic, CosmicRenderer{}, "COSMIC-INFLUENCED PREDICTIONS"},
+		{"unknown", DetailedRenderer{}, "RECOMMENDED NUMBER SETS:"},
+	}
+
+	for _, tc := range testCases {
+		renderer := ConsoleRenderer(tc.mode)
+		s.IsType(tc.renderer, renderer, tc.mode)
+		s.Contains(s.renderReport(s.analyzer, renderer), tc.contains, tc.mode)
+	}
+}
+
+// TestRendererWriteError tests that write failures are reported
+func (s *AnalyzerTestSuite) TestRendererWriteError() {
+	report, err := s.analyzer.BuildReport(context.Background())
+	s.Require().NoError(err)
+
+	for _, mode := range []string{OutputModeDetailed, OutputModeSimple, OutputModeStatistical, OutputModeCosmic} {
+		s.Error(ConsoleRenderer(mode).Render(errWriter{}, report), mode)
+	}
+}
+
+// TestWriteAnalysisSeparatesProgress tests that progress messages stay out of the report
+func (s *AnalyzerTestSuite) TestWriteAnalysisSeparatesProgress() {
+	var report, progress bytes.Buffer
+	s.analyzer.SetProgressWriter(&progress)
+
+	s.Require().NoError(s.analyzer.WriteAnalysis(context.Background(), &report))
+	s.Contains(report.String(), "QUICK PICKS:")
+	s.NotContains(report.String(), "Fetching Cosmic Data")
+	s.Contains(progress.String(), "Fetching Cosmic Data")
+	s.Contains(progress.String(), "Completed")
+
+	// A nil progress writer discards progress
+	s.analyzer.SetProgressWriter(nil)
+	report.Reset()
+	s.Require().NoError(s.analyzer.WriteAnalysis(context.Background(), &report))
+	s.False(strings.Contains(report.String(), "Analyzing Cosmic Correlations"))
+
+	// The default progress writer is stderr
+	s.Equal(os.Stderr, (&Analyzer{}).progressWriter())
+}
+
+// TestStructuredRenderers tests the JSON and NDJSON renderers
+func (s *AnalyzerTestSuite) TestStructuredRenderers() {
+	var report bytes.Buffer
+	s.analyzer.SetProgressWriter(nil)
+	s.Require().NoError(s.analyzer.WriteReport(context.Background(), &report, JSONRenderer{}))
+
+	var decoded Report
+	s.Require().NoError(json.Unmarshal(report.Bytes(), &decoded))
+	s.Equal(5, decoded.TotalDrawings)
+	s.Len(decoded.HotNumbers, reportNumberCount)
+	s.Len(decoded.Recommendations, reportRecommendationCount)
+	s.Len(decoded.CosmicPick, 5)
+
+	// NDJSON starts with the summary and has one record per line
+	report.Reset()
+	s.Require().NoError(s.analyzer.WriteReport(context.Background(), &report, NDJSONRenderer{}))
+	counts := make(map[string]int)
+	for i, line := range strings.Split(strings.TrimSpace(report.String()), "\n") {
+		var record struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal([]byte(line), &record), line)
+		s.NotEmpty(record.Data)
+		if i == 0 {
+			s.Equal(RecordTypeSummary, record.Type)
+		}
+		counts[record.Type]++
+	}
+	s.Equal(reportNumberCount, counts[RecordTypeHotNumber])
+	s.Len(decoded.OverdueNumbers, counts[RecordTypeOverdueNumber])
+	s.Equal(reportRecommendationCount, counts[RecordTypeRecommendation])
+	s.Equal(1, counts[RecordTypeCosmicPick])
+	s.Equal(1, counts[RecordTypePairMatrix])
+	s.Positive(counts[RecordTypeCorrelation])
+}
+
+// TestNewRenderer tests renderer selection by output format
+func (s *AnalyzerTestSuite) TestNewRenderer() {
+	renderer, err := NewRenderer(OutputModeStatistical, OutputFormatText)
+	s.Require().NoError(err)
+	s.IsType(StatisticalRenderer{}, renderer)
+
+	renderer, err = NewRenderer(OutputModeStatistical, OutputFormatJSON)
+	s.Require().NoError(err)
+	s.IsType(JSONRenderer{}, renderer)
+
+	renderer, err = NewRenderer(OutputModeSimple, OutputFormatNDJSON)
+	s.Require().NoError(err)
+	s.IsType(NDJSONRenderer{}, renderer)
+
+	_, err = NewRenderer(OutputModeSimple, "xml")
+	s.Require().ErrorIs(err, ErrUnknownOutputFormat)
+
+	// Encoding failures are reported
+	report, err := s.analyzer.BuildReport(context.Background())
+	s.Require().NoError(err)
+	s.Error(JSONRenderer{}.Render(errWriter{}, report))
+	s.Error(NDJSONRenderer{}.Render(errWriter{}, report))
+}
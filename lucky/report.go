@@ -0,0 +1,220 @@
+package lucky
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// Report section sizes
+	reportNumberCount         = 10 // Hot, frequent and overdue numbers
+	reportRecommendationCount = 5  // Recommended sets
+	reportPairCount           = 5  // Top pairs
+)
+
+// Report is a snapshot of an analysis that renderers turn into output
+type Report struct {
+	Mode                string               `json:"mode"`
+	Game                GameSpec             `json:"game"`
+	GeneratedAt         time.Time            `json:"generated_at"`
+	TotalDrawings       int                  `json:"total_drawings"`
+	FirstDrawing        time.Time            `json:"first_drawing"`
+	LastDrawing         time.Time            `json:"last_drawing"`
+	RecentWindow        int                  `json:"recent_window"`
+	ChiSquare           float64              `json:"chi_square"` // Main and bonus statistics combined
+	MainChiSquare       ChiSquareTest        `json:"main_chi_square"`
+	BonusChiSquare      *ChiSquareTest       `json:"bonus_chi_square,omitempty"` // Nil for games without a bonus ball
+	RandomnessScore     float64              `json:"randomness_score"`
+	HotNumbers          []NumberInfo         `json:"hot_numbers"`      // Most frequent in the recent window
+	FrequentNumbers     []NumberInfo         `json:"frequent_numbers"` // Most frequent of all time
+	OverdueNumbers      []NumberInfo         `json:"overdue_numbers"`
+	OddEvenPatterns     []PatternCount       `json:"odd_even_patterns"` // Most common first
+	ConsecutiveCount    int                  `json:"consecutive_count"`
+	TopPairs            []CombinationPattern `json:"top_pairs"`
+	Recommendations     []RecommendedSet     `json:"recommendations"`
+	CosmicPick          []int                `json:"cosmic_pick"`
+	CosmicLuckyBall     int                  `json:"cosmic_lucky_ball,omitempty"` // Bonus ball of the cosmic pick; 0 for games without one
+	Correlations        []CorrelationResult  `json:"correlations"`
+	MultipleComparisons MultipleComparisons  `json:"multiple_comparisons"` // Correlations surviving correction
+	CosmicConditions    CosmicConditions     `json:"cosmic_conditions"`
+	Distribution        DistributionStats    `json:"distribution"`
+	PatternFits         []PatternFit         `json:"pattern_fits"` // Odd/even, sum range and decade patterns against a fair draw
+	PairMatrix          PairMatrix           `json:"pair_matrix"`  // Every pair's co-occurrence count and the pairs chance does not explain
+}
+
+// PatternCount is the number of drawings that matched a pattern label (e.g. "3O-2E")
+type PatternCount struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+}
+
+// CosmicConditions describes the sky on a given day
+type CosmicConditions struct {
+	Date             time.Time `json:"date"`
+	MoonPhaseName    string    `json:"moon_phase_name"`
+	MoonIllumination float64   `json:"moon_illumination"` // 0-1
+	ZodiacSign       string    `json:"zodiac_sign"`
+	DayOfWeek        string    `json:"day_of_week"`
+}
+
+// DistributionStats summarizes how main number frequencies and gaps are spread
+type DistributionStats struct {
+	ExpectedFrequency      float64 `json:"expected_frequency"`
+	StandardDeviation      float64 `json:"standard_deviation"`
+	CoefficientOfVariation float64 `json:"coefficient_of_variation"` // Percent
+	OutsideTwoSigma        int     `json:"outside_two_sigma"`
+	AverageGap             float64 `json:"average_gap"`
+	MinGap                 int     `json:"min_gap"`
+	MaxGap                 int     `json:"max_gap"`
+}
+
+// BuildReport snapshots the analysis into a Report.
+//
+// Correlation results are included as of the last CorrelationEngine().AnalyzeCorrelations run.
+func (a *Analyzer) BuildReport(ctx context.Context) (*Report, error) {
+	patterns := a.PatternStats()
+	report := &Report{
+		Game:             a.Game(),
+		GeneratedAt:      time.Now(),
+		TotalDrawings:    len(a.drawings),
+		ChiSquare:        a.chiSquareValue,
+		MainChiSquare:    a.mainChiSquare,
+		RandomnessScore:  a.randomnessScore,
+		HotNumbers:       derefNumberInfos(a.GetTopNumbers(reportNumberCount, true)),
+		FrequentNumbers:  derefNumberInfos(a.GetTopNumbers(reportNumberCount, false)),
+		OverdueNumbers:   derefNumberInfos(a.GetOverdueNumbers(reportNumberCount)),
+		OddEvenPatterns:  sortPatternCounts(patterns.OddEvenPatterns),
+		ConsecutiveCount: patterns.ConsecutiveCount,
+		TopPairs:         topPatterns(a.pairPatterns, reportPairCount),
+		Distribution:     a.distributionStats(),
+		PatternFits:      a.PatternFits(),
+		PairMatrix:       a.PairMatrix(),
+	}
+	if a.spec().HasBonus() {
+		bonus := a.bonusChiSquare
+		report.BonusChiSquare = &bonus
+	}
+	if a.config != nil {
+		report.Mode = a.config.OutputMode
+		report.RecentWindow = a.config.RecentWindow
+	}
+	if len(a.drawings) > 0 {
+		report.FirstDrawing = a.drawings[len(a.drawings)-1].Date
+		report.LastDrawing = a.drawings[0].Date
+	}
+
+	recommendations, err := a.GenerateRecommendations(ctx, reportRecommendationCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recommendations: %w", err)
+	}
+	report.Recommendations = recommendations
+
+	if ce := a.correlationEngine; ce != nil {
+		pick := ce.PredictBasedOnCosmicConditions()
+		report.CosmicPick, report.CosmicLuckyBall = pick.Numbers, pick.LuckyBall
+		report.Correlations = ce.CorrelationResults()
+		report.MultipleComparisons = ce.MultipleComparisons()
+		report.CosmicConditions = ce.CurrentConditions(report.GeneratedAt)
+	}
+
+	return report, nil
+}
+
+// sortPatternCounts returns pattern counts, most common first
+func sortPatternCounts(counts map[string]int) []PatternCount {
+	patterns := make([]PatternCount, 0, len(counts))
+	for pattern, count := range counts {
+		patterns = append(patterns, PatternCount{Pattern: pattern, Count: count})
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Count != patterns[j].Count {
+			return patterns[i].Count > patterns[j].Count
+		}
+		return patterns[i].Pattern < patterns[j].Pattern
+	})
+	return patterns
+}
+
+// topPatterns returns up to count combinations, most frequent first
+func topPatterns(patterns map[string]*CombinationPattern, count int) []CombinationPattern {
+	pairs := make([]CombinationPattern, 0, len(patterns))
+	for _, pattern := range copyPatterns(patterns) {
+		pairs = append(pairs, pattern)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Frequency != pairs[j].Frequency {
+			return pairs[i].Frequency > pairs[j].Frequency
+		}
+		return pairs[i].Key < pairs[j].Key
+	})
+	if count < len(pairs) {
+		pairs = pairs[:count]
+	}
+	return pairs
+}
+
+// distributionStats measures the spread of main number frequencies and gaps
+func (a *Analyzer) distributionStats() DistributionStats {
+	game := a.spec()
+	stats := DistributionStats{
+		ExpectedFrequency: float64(len(a.drawings)) * float64(game.MainPicks) / float64(game.MainPoolSize),
+		MinGap:            999999,
+	}
+
+	var sumSquaredDiff float64
+	for _, info := range a.mainNumbers {
+		diff := float64(info.TotalFrequency) - stats.ExpectedFrequency
+		sumSquaredDiff += diff * diff
+	}
+	stats.StandardDeviation = math.Sqrt(sumSquaredDiff / float64(game.MainPoolSize))
+	stats.CoefficientOfVariation = (stats.StandardDeviation / stats.ExpectedFrequency) * 100
+
+	// Numbers outside normal range
+	for _, info := range a.mainNumbers {
+		if math.Abs(float64(info.TotalFrequency)-stats.ExpectedFrequency) > 2*stats.StandardDeviation {
+			stats.OutsideTwoSigma++
+		}
+	}
+
+	// Gap lengths
+	totalGaps := 0
+	for _, info := range a.mainNumbers {
+		for _, gap := range info.GapsSinceDrawn {
+			totalGaps++
+			if gap < stats.MinGap {
+				stats.MinGap = gap
+			}
+			if gap > stats.MaxGap {
+				stats.MaxGap = gap
+			}
+		}
+	}
+	stats.AverageGap = float64(totalGaps) / float64(len(a.mainNumbers))
+
+	return stats
+}
+
+// CurrentConditions returns the moon phase, zodiac sign and weekday for a date
+func (ce *CorrelationEngine) CurrentConditions(date time.Time) CosmicConditions {
+	phase, illumination := ce.calculateMoonPhase(date)
+	return CosmicConditions{
+		Date:             date,
+		MoonPhaseName:    ce.getMoonPhaseName(phase),
+		MoonIllumination: illumination,
+		ZodiacSign:       ce.getZodiacSign(date),
+		DayOfWeek:        date.Weekday().String(),
+	}
+}
+
+// derefNumberInfos copies number statistics into a value slice
+func derefNumberInfos(infos []*NumberInfo) []NumberInfo {
+	out := make([]NumberInfo, len(infos))
+	for i, info := range infos {
+		out[i] = *info
+		out[i].GapsSinceDrawn = append([]int(nil), info.GapsSinceDrawn...)
+	}
+	return out
+}
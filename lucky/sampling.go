@@ -0,0 +1,97 @@
+package lucky
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// DefaultSamplingTemperature is the temperature the CLI samples recommended sets at
+const DefaultSamplingTemperature = 1.0
+
+// samplingTemperature returns the configured temperature; zero or less means greedy top picks
+func (a *Analyzer) samplingTemperature() float64 {
+	if a.config == nil || !(a.config.Temperature > 0) {
+		return 0
+	}
+	return a.config.Temperature
+}
+
+// newSampler returns the random source for sampling recommended sets from the configured seed
+func (a *Analyzer) newSampler() *rand.Rand {
+	var seed int64
+	if a.config != nil {
+		seed = a.config.Seed
+	}
+	return rand.New(rand.NewPCG(uint64(seed), uint64(seed))) //nolint:gosec // reproducible picks need a seeded generator, not a secure one
+}
+
+// orderScores ranks scores best first, or in a score-weighted random order when sampling
+func (a *Analyzer) orderScores(scored []ScoredNumber, rng *rand.Rand) []ScoredNumber {
+	if temperature := a.samplingTemperature(); temperature > 0 && rng != nil {
+		return sampleRanking(scored, temperature, rng)
+	}
+	return rankScores(scored)
+}
+
+// sampleRanking orders scored numbers by weighted random sampling without replacement: each number's
+// chance of coming next is proportional to its weight among the numbers not yet drawn. Adding Gumbel
+// noise to the log weights and sorting gives exactly that order in one pass.
+func sampleRanking(scored []ScoredNumber, temperature float64, rng *rand.Rand) []ScoredNumber {
+	scored = rankScores(scored) // Strategies may score in any order; the same seed must give the same picks
+	logWeights := samplingLogWeights(scored, temperature)
+	keys := make([]float64, len(scored))
+	order := make([]int, len(scored))
+	for i, logWeight := range logWeights {
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+		keys[i] = logWeight - math.Log(-math.Log(u))
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] > keys[order[j]] })
+
+	ranked := make([]ScoredNumber, len(scored))
+	for i, idx := range order {
+		ranked[i] = scored[idx]
+	}
+	return ranked
+}
+
+// samplingLogWeights returns the log sampling weight of each score: its distance below the best score in
+// standard deviations, divided by the temperature, so a temperature means the same for every strategy's
+// scale. Higher temperatures flatten the weights toward uniform; lower ones approach the top picks.
+// Scores that are not finite always come last.
+func samplingLogWeights(scored []ScoredNumber, temperature float64) []float64 {
+	finite := make([]float64, 0, len(scored))
+	for _, sn := range scored {
+		if !math.IsNaN(sn.Score) && !math.IsInf(sn.Score, 0) {
+			finite = append(finite, sn.Score)
+		}
+	}
+	best, sd := math.Inf(-1), 0.0
+	if len(finite) > 0 {
+		mean := 0.0
+		for _, score := range finite {
+			mean += score
+			best = math.Max(best, score)
+		}
+		mean /= float64(len(finite))
+		for _, score := range finite {
+			sd += (score - mean) * (score - mean)
+		}
+		sd = math.Sqrt(sd / float64(len(finite)))
+	}
+
+	logWeights := make([]float64, len(scored))
+	for i, sn := range scored {
+		switch {
+		case math.IsNaN(sn.Score) || math.IsInf(sn.Score, 0):
+			logWeights[i] = math.Inf(-1)
+		case sd > 0:
+			logWeights[i] = (sn.Score - best) / sd / temperature
+		}
+	}
+	return logWeights
+}
@@ -0,0 +1,98 @@
+package lucky
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// TestSamplingLogWeights tests that weights ignore the score scale and follow the temperature
+func (s *AnalyzerTestSuite) TestSamplingLogWeights() {
+	scored := []ScoredNumber{{Number: 1, Score: 0}, {Number: 2, Score: 1}}
+	weights := samplingLogWeights(scored, 1)
+	s.InDelta(-2.0, weights[0], 1e-12) // Two standard deviations below the best
+	s.InDelta(0.0, weights[1], 1e-12)
+
+	scaled := samplingLogWeights([]ScoredNumber{{Number: 1, Score: 0}, {Number: 2, Score: 100}}, 1)
+	s.InDeltaSlice(weights, scaled, 1e-12)
+	s.InDelta(-0.5, samplingLogWeights(scored, 4)[0], 1e-12)
+
+	// Equal scores are uniform; non-finite scores are never preferred
+	s.Equal([]float64{0, 0}, samplingLogWeights([]ScoredNumber{{Number: 1, Score: 3}, {Number: 2, Score: 3}}, 1))
+	withNaN := samplingLogWeights([]ScoredNumber{{Number: 1, Score: math.NaN()}, {Number: 2, Score: 3}}, 1)
+	s.True(math.IsInf(withNaN[0], -1))
+}
+
+// TestSampleRanking tests that the first sampled number follows the softmax weights
+func (s *AnalyzerTestSuite) TestSampleRanking() {
+	scored := []ScoredNumber{{Number: 1, Score: 0}, {Number: 2, Score: 1}}
+	rng := rand.New(rand.NewPCG(7, 7)) //nolint:gosec // test randomness
+	const trials = 4000
+	first := 0
+	for i := 0; i < trials; i++ {
+		ranked := sampleRanking(scored, 1, rng)
+		s.Require().Len(ranked, 2)
+		if ranked[0].Number == 2 {
+			first++
+		}
+	}
+	s.InDelta(1/(1+math.Exp(-2)), float64(first)/trials, 0.02)
+
+	// A tiny temperature always takes the top pick
+	for i := 0; i < 100; i++ {
+		s.Equal(2, sampleRanking(scored, 1e-6, rng)[0].Number)
+	}
+}
+
+// TestSampledRecommendations tests that seeded sampling gives distinct, valid and repeatable sets
+func (s *AnalyzerTestSuite) TestSampledRecommendations() {
+	ctx := context.Background()
+	s.analyzer.config.Strategies = []string{"frequency"}
+	s.analyzer.config.Temperature = 2
+	s.analyzer.config.Seed = 42
+
+	sets, err := s.analyzer.GenerateRecommendations(ctx, 6)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 6)
+	distinct := make(map[string]bool)
+	for _, set := range sets {
+		s.Len(set.Numbers, 5)
+		s.True(sort.IntsAreSorted(set.Numbers))
+		for i, num := range set.Numbers {
+			s.True(s.analyzer.spec().ValidMain(num))
+			if i > 0 {
+				s.NotEqual(set.Numbers[i-1], num)
+			}
+		}
+		s.True(s.analyzer.spec().ValidBonus(set.LuckyBall))
+		distinct[formatTicket(s.analyzer.Game(), set.Ticket())] = true
+	}
+	s.Greater(len(distinct), 1)
+
+	again, err := s.analyzer.GenerateRecommendations(ctx, 6)
+	s.Require().NoError(err)
+	s.Equal(sets, again)
+
+	s.analyzer.config.Seed = 43
+	other, err := s.analyzer.GenerateRecommendations(ctx, 6)
+	s.Require().NoError(err)
+	s.NotEqual(sets, other)
+
+	// Temperature zero goes back to the top picks
+	s.analyzer.config.Temperature = 0
+	greedy, err := s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().NoError(err)
+	top := pickScored(rankScores(s.analyzer.mainScores("frequency")), 5, s.analyzer.spec().ValidMain, nil)
+	sort.Ints(top)
+	s.Equal(top, greedy[0].Numbers)
+}
+
+// mainScores returns a registered strategy's main number scores
+func (a *Analyzer) mainScores(name string) []ScoredNumber {
+	strategy, err := a.Strategies().Lookup(name)
+	if err != nil {
+		return nil
+	}
+	return strategy.ScoreMain(a)
+}
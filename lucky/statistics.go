@@ -0,0 +1,314 @@
+package lucky
+
+import (
+	"math"
+)
+
+const (
+	// defaultConfidenceLevel is used when the analyzer has no valid configured level
+	defaultConfidenceLevel = 0.95
+
+	// Numerical tolerances of the special functions
+	gammaEpsilon       = 1e-15
+	gammaMaxIterations = 1000
+	gammaTiny          = 1e-300
+	quantileTolerance  = 1e-10
+)
+
+// ChiSquareTest is the result of a chi-square goodness-of-fit test against a uniform draw
+type ChiSquareTest struct {
+	Statistic        float64 `json:"statistic"`
+	DegreesOfFreedom int     `json:"degrees_of_freedom"`
+	PValue           float64 `json:"p_value"`        // P(X >= Statistic) under a fair draw
+	CriticalValue    float64 `json:"critical_value"` // Statistic needed to reject at ConfidenceLevel
+	ConfidenceLevel  float64 `json:"confidence_level"`
+	RejectsUniform   bool    `json:"rejects_uniform"` // Statistic exceeds CriticalValue
+}
+
+// newChiSquareTest evaluates a chi-square statistic with df degrees of freedom at a confidence level
+func newChiSquareTest(statistic float64, df int, confidence float64) ChiSquareTest {
+	test := ChiSquareTest{
+		Statistic:        statistic,
+		DegreesOfFreedom: df,
+		PValue:           ChiSquarePValue(statistic, df),
+		CriticalValue:    ChiSquareCritical(df, confidence),
+		ConfidenceLevel:  confidence,
+	}
+	test.RejectsUniform = df > 0 && statistic > test.CriticalValue
+	return test
+}
+
+// ChiSquarePValue returns the upper-tail probability of a chi-square statistic with df degrees of freedom
+func ChiSquarePValue(statistic float64, df int) float64 {
+	if df <= 0 {
+		return 1
+	}
+	if statistic <= 0 {
+		return 1
+	}
+	return RegularizedGammaQ(float64(df)/2, statistic/2)
+}
+
+// ChiSquareCritical returns the chi-square value whose lower-tail probability is confidence
+func ChiSquareCritical(df int, confidence float64) float64 {
+	if df <= 0 || confidence <= 0 {
+		return 0
+	}
+	if confidence >= 1 {
+		return math.Inf(1)
+	}
+
+	// Bracket the quantile, then bisect on the lower-tail probability
+	k := float64(df) / 2
+	low, high := 0.0, math.Max(1, float64(df))
+	for RegularizedGammaP(k, high/2) < confidence {
+		low, high = high, high*2
+	}
+	for high-low > quantileTolerance*math.Max(1, high) {
+		mid := (low + high) / 2
+		if RegularizedGammaP(k, mid/2) < confidence {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// RegularizedGammaP returns the regularized lower incomplete gamma function P(s, x)
+func RegularizedGammaP(s, x float64) float64 {
+	switch {
+	case s <= 0 || x < 0 || math.IsNaN(x):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case math.IsInf(x, 1):
+		return 1
+	case x < s+1:
+		return gammaSeries(s, x)
+	default:
+		return 1 - gammaContinuedFraction(s, x)
+	}
+}
+
+// RegularizedGammaQ returns the regularized upper incomplete gamma function Q(s, x) = 1 - P(s, x)
+func RegularizedGammaQ(s, x float64) float64 {
+	switch {
+	case s <= 0 || x < 0 || math.IsNaN(x):
+		return math.NaN()
+	case x == 0:
+		return 1
+	case math.IsInf(x, 1):
+		return 0
+	case x < s+1:
+		return 1 - gammaSeries(s, x)
+	default:
+		return gammaContinuedFraction(s, x)
+	}
+}
+
+// gammaSeries evaluates P(s, x) by its power series, which converges quickly for x < s+1
+func gammaSeries(s, x float64) float64 {
+	lgamma, _ := math.Lgamma(s)
+	term := 1 / s
+	sum := term
+	for n := 1; n <= gammaMaxIterations; n++ {
+		term *= x / (s + float64(n))
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+	return sum * math.Exp(-x+s*math.Log(x)-lgamma)
+}
+
+// gammaContinuedFraction evaluates Q(s, x) by Lentz's continued fraction, which converges quickly for x >= s+1
+func gammaContinuedFraction(s, x float64) float64 {
+	lgamma, _ := math.Lgamma(s)
+	b := x + 1 - s
+	c := 1 / gammaTiny
+	d := 1 / b
+	h := d
+	for n := 1; n <= gammaMaxIterations; n++ {
+		an := -float64(n) * (float64(n) - s)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < gammaTiny {
+			d = gammaTiny
+		}
+		c = b + an/c
+		if math.Abs(c) < gammaTiny {
+			c = gammaTiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+	return math.Exp(-x+s*math.Log(x)-lgamma) * h
+}
+
+// ConfidenceInterval is a two-sided interval for an estimate at a confidence level
+type ConfidenceInterval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Level float64 `json:"level"`
+}
+
+// StudentTTwoSidedPValue returns P(|T| >= |t|) for Student's t distribution with df degrees of freedom
+func StudentTTwoSidedPValue(t, df float64) float64 {
+	switch {
+	case df <= 0 || math.IsNaN(t):
+		return math.NaN()
+	case math.IsInf(t, 0):
+		return 0
+	}
+	return RegularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// StudentTCDF returns P(T <= t) for Student's t distribution with df degrees of freedom
+func StudentTCDF(t, df float64) float64 {
+	tail := StudentTTwoSidedPValue(t, df) / 2
+	if t < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+// RegularizedIncompleteBeta returns the regularized incomplete beta function I_x(a, b)
+func RegularizedIncompleteBeta(a, b, x float64) float64 {
+	switch {
+	case a <= 0 || b <= 0 || x < 0 || x > 1 || math.IsNaN(x):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case x == 1:
+		return 1
+	}
+
+	lgammaAB, _ := math.Lgamma(a + b)
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log1p(-x))
+
+	// The continued fraction converges quickly below the mean; use the symmetry relation above it
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete beta function by Lentz's method
+func betaContinuedFraction(a, b, x float64) float64 {
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < gammaTiny {
+		d = gammaTiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= gammaMaxIterations; m++ {
+		fm := float64(m)
+
+		// Even step
+		an := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + an*d
+		if math.Abs(d) < gammaTiny {
+			d = gammaTiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < gammaTiny {
+			c = gammaTiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		an = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + an*d
+		if math.Abs(d) < gammaTiny {
+			d = gammaTiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < gammaTiny {
+			c = gammaTiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+	return h
+}
+
+// NormalQuantile returns the value below which a standard normal variable falls with probability p
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// PearsonPValue returns the two-sided p-value of a Pearson correlation r over n pairs
+func PearsonPValue(r float64, n int) float64 {
+	if n < 3 || math.IsNaN(r) {
+		return 1
+	}
+	if r*r >= 1 {
+		return 0
+	}
+	df := float64(n - 2)
+	t := r * math.Sqrt(df/(1-r*r))
+	return StudentTTwoSidedPValue(t, df)
+}
+
+// BinomialUpperTail returns P(X >= k) for X binomial over n trials with success probability p
+func BinomialUpperTail(k, n int, p float64) float64 {
+	switch {
+	case n < 0 || p < 0 || p > 1 || math.IsNaN(p):
+		return math.NaN()
+	case k <= 0:
+		return 1
+	case k > n:
+		return 0
+	}
+	return RegularizedIncompleteBeta(float64(k), float64(n-k+1), p)
+}
+
+// BinomialLowerTail returns P(X <= k) for X binomial over n trials with success probability p
+func BinomialLowerTail(k, n int, p float64) float64 {
+	switch {
+	case n < 0 || p < 0 || p > 1 || math.IsNaN(p):
+		return math.NaN()
+	case k < 0:
+		return 0
+	case k >= n:
+		return 1
+	}
+	return RegularizedIncompleteBeta(float64(n-k), float64(k+1), 1-p)
+}
+
+// BinomialTwoSidedPValue returns the two-sided p-value of k successes in n trials with success
+// probability p: twice the smaller tail, capped at 1
+func BinomialTwoSidedPValue(k, n int, p float64) float64 {
+	return math.Min(1, 2*math.Min(BinomialLowerTail(k, n, p), BinomialUpperTail(k, n, p)))
+}
+
+// FisherConfidenceInterval returns the Fisher z-transform interval of a Pearson correlation r over n pairs.
+// It reports false when n is too small (n <= 3) for the interval to exist.
+func FisherConfidenceInterval(r float64, n int, level float64) (ConfidenceInterval, bool) {
+	if n <= 3 || math.IsNaN(r) || level <= 0 || level >= 1 {
+		return ConfidenceInterval{}, false
+	}
+
+	// Keep perfect correlations finite so the interval collapses onto them
+	r = math.Max(-1+gammaEpsilon, math.Min(1-gammaEpsilon, r))
+	z := math.Atanh(r)
+	margin := NormalQuantile(1-(1-level)/2) / math.Sqrt(float64(n-3))
+	return ConfidenceInterval{
+		Lower: math.Tanh(z - margin),
+		Upper: math.Tanh(z + margin),
+		Level: level,
+	}, true
+}
@@ -0,0 +1,186 @@
+package lucky
+
+import (
+	"math"
+)
+
+// TestRegularizedGamma tests the incomplete gamma functions against closed forms
+func (s *AnalyzerTestSuite) TestRegularizedGamma() {
+	for _, x := range []float64{0.01, 0.5, 1, 2.5, 10, 40} {
+		// P(1, x) = 1 - e^-x and P(1/2, x) = erf(sqrt(x))
+		s.InDelta(1-math.Exp(-x), RegularizedGammaP(1, x), 1e-12, "x=%g", x)
+		s.InDelta(math.Erf(math.Sqrt(x)), RegularizedGammaP(0.5, x), 1e-12, "x=%g", x)
+
+		// P and Q are complementary on both sides of the series/continued fraction switch
+		for _, a := range []float64{0.5, 3, 8.5, 23.5} {
+			s.InDelta(1.0, RegularizedGammaP(a, x)+RegularizedGammaQ(a, x), 1e-12, "a=%g x=%g", a, x)
+		}
+	}
+
+	// Boundaries
+	s.InDelta(0.0, RegularizedGammaP(2, 0), 0)
+	s.InDelta(1.0, RegularizedGammaQ(2, 0), 0)
+	s.InDelta(1.0, RegularizedGammaP(2, math.Inf(1)), 0)
+	s.InDelta(0.0, RegularizedGammaQ(2, math.Inf(1)), 0)
+	s.True(math.IsNaN(RegularizedGammaP(0, 1)))
+	s.True(math.IsNaN(RegularizedGammaQ(1, -1)))
+}
+
+// TestChiSquarePValue tests p-values against published chi-square tables
+func (s *AnalyzerTestSuite) TestChiSquarePValue() {
+	s.InDelta(0.05, ChiSquarePValue(3.841458820694124, 1), 1e-9)
+	s.InDelta(0.01, ChiSquarePValue(6.634896601021214, 1), 1e-9)
+	s.InDelta(0.05, ChiSquarePValue(64.00111197, 47), 1e-6)
+	s.InDelta(0.05, ChiSquarePValue(27.58711164, 17), 1e-6)
+	s.InDelta(math.Exp(-2.5), ChiSquarePValue(5, 2), 1e-12)
+
+	// No evidence against uniformity without a statistic or degrees of freedom
+	s.InDelta(1.0, ChiSquarePValue(0, 47), 0)
+	s.InDelta(1.0, ChiSquarePValue(10, 0), 0)
+}
+
+// TestChiSquareCritical tests critical values against published tables
+func (s *AnalyzerTestSuite) TestChiSquareCritical() {
+	s.InDelta(3.8415, ChiSquareCritical(1, 0.95), 1e-4)
+	s.InDelta(64.0011, ChiSquareCritical(47, 0.95), 1e-4)
+	s.InDelta(27.5871, ChiSquareCritical(17, 0.95), 1e-4)
+	s.InDelta(23.2093, ChiSquareCritical(10, 0.99), 1e-4)
+	s.InDelta(15.9872, ChiSquareCritical(10, 0.90), 1e-4)
+	s.InDelta(0.0, ChiSquareCritical(0, 0.95), 0)
+	s.True(math.IsInf(ChiSquareCritical(5, 1), 1))
+}
+
+// TestChiSquareTests tests that the analyzer reports separate tests at the configured confidence
+func (s *AnalyzerTestSuite) TestChiSquareTests() {
+	main := s.analyzer.MainChiSquare()
+	bonus := s.analyzer.BonusChiSquare()
+	s.Equal(47, main.DegreesOfFreedom)
+	s.Equal(17, bonus.DegreesOfFreedom)
+	s.InDelta(s.analyzer.ChiSquareValue(), main.Statistic+bonus.Statistic, 1e-9)
+	s.InDelta(ChiSquarePValue(main.Statistic, 47), main.PValue, 1e-12)
+	s.InDelta(0.95, main.ConfidenceLevel, 0)
+	s.Equal(main.Statistic > main.CriticalValue, main.RejectsUniform)
+
+	// A looser confidence level lowers the critical values
+	s.analyzer.config.ConfidenceLevel = 0.80
+	s.analyzer.calculateChiSquare()
+	s.InDelta(ChiSquareCritical(47, 0.80), s.analyzer.MainChiSquare().CriticalValue, 1e-9)
+	s.Less(s.analyzer.MainChiSquare().CriticalValue, main.CriticalValue)
+
+	// Games without a bonus ball have no bonus test
+	report, err := s.analyzer.BuildReport(s.T().Context())
+	s.Require().NoError(err)
+	s.Require().NotNil(report.BonusChiSquare)
+	s.analyzer.game = &GameSpec{Key: "digits", MinNumber: 0, MainPoolSize: 10, MainPicks: 3, AllowRepeats: true}
+	s.analyzer.calculateChiSquare()
+	s.Equal(ChiSquareTest{}, s.analyzer.BonusChiSquare())
+}
+
+// TestIncompleteBeta tests the regularized incomplete beta function against closed forms
+func (s *AnalyzerTestSuite) TestIncompleteBeta() {
+	for _, x := range []float64{0.05, 0.3, 0.5, 0.8, 0.99} {
+		s.InDelta(x, RegularizedIncompleteBeta(1, 1, x), 1e-12, "x=%g", x)
+		s.InDelta(math.Pow(x, 3.5), RegularizedIncompleteBeta(3.5, 1, x), 1e-12, "x=%g", x)
+
+		// Symmetry: I_x(a, b) = 1 - I_{1-x}(b, a)
+		s.InDelta(1-RegularizedIncompleteBeta(4, 2.5, 1-x), RegularizedIncompleteBeta(2.5, 4, x), 1e-12, "x=%g", x)
+	}
+	s.InDelta(0.0, RegularizedIncompleteBeta(2, 3, 0), 0)
+	s.InDelta(1.0, RegularizedIncompleteBeta(2, 3, 1), 0)
+	s.True(math.IsNaN(RegularizedIncompleteBeta(0, 3, 0.5)))
+	s.True(math.IsNaN(RegularizedIncompleteBeta(2, 3, 1.5)))
+}
+
+// TestStudentT tests Student's t probabilities against published tables
+func (s *AnalyzerTestSuite) TestStudentT() {
+	s.InDelta(0.05, StudentTTwoSidedPValue(2.228138851986274, 10), 1e-9)
+	s.InDelta(0.01, StudentTTwoSidedPValue(-2.845339709785, 20), 1e-9)
+	s.InDelta(0.5, StudentTTwoSidedPValue(1, 1), 1e-12) // Cauchy: P(|T| >= 1) = 1/2
+	s.InDelta(1.0, StudentTTwoSidedPValue(0, 7), 1e-12)
+	s.InDelta(0.0, StudentTTwoSidedPValue(math.Inf(-1), 7), 0)
+	s.True(math.IsNaN(StudentTTwoSidedPValue(1, 0)))
+
+	s.InDelta(0.5, StudentTCDF(0, 5), 1e-12)
+	s.InDelta(0.975, StudentTCDF(2.228138851986274, 10), 1e-9)
+	s.InDelta(0.025, StudentTCDF(-2.228138851986274, 10), 1e-9)
+}
+
+// TestBinomialTails tests binomial tail probabilities against direct sums of the probability mass
+func (s *AnalyzerTestSuite) TestBinomialTails() {
+	n, p := 20, 0.3
+	mass := make([]float64, n+1)
+	for k := range mass {
+		mass[k] = math.Exp(logChoose(n, k)) * math.Pow(p, float64(k)) * math.Pow(1-p, float64(n-k))
+	}
+	for k := 0; k <= n; k++ {
+		upper, lower := 0.0, 0.0
+		for j, m := range mass {
+			if j >= k {
+				upper += m
+			}
+			if j <= k {
+				lower += m
+			}
+		}
+		s.InDelta(upper, BinomialUpperTail(k, n, p), 1e-12, "k=%d", k)
+		s.InDelta(lower, BinomialLowerTail(k, n, p), 1e-12, "k=%d", k)
+	}
+
+	s.InDelta(0.109375, BinomialTwoSidedPValue(2, 10, 0.5), 1e-12) // R: binom.test(2, 10)
+	s.InDelta(1.0, BinomialTwoSidedPValue(5, 10, 0.5), 0)
+	s.InDelta(0.0, BinomialUpperTail(11, 10, 0.5), 0)
+	s.True(math.IsNaN(BinomialUpperTail(1, 10, 1.5)))
+}
+
+// TestPearsonInference tests correlation p-values and Fisher z intervals
+func (s *AnalyzerTestSuite) TestPearsonInference() {
+	// r = 0.5 over 20 pairs gives t = 2.4495 with 18 degrees of freedom
+	s.InDelta(0.0247696, PearsonPValue(0.5, 20), 1e-6)
+	s.InDelta(PearsonPValue(0.5, 20), PearsonPValue(-0.5, 20), 1e-12)
+	s.InDelta(0.0, PearsonPValue(1, 20), 0)
+	s.InDelta(1.0, PearsonPValue(0.9, 2), 0)
+
+	s.InDelta(1.959964, NormalQuantile(0.975), 1e-6)
+
+	ci, ok := FisherConfidenceInterval(0.5, 50, 0.95)
+	s.Require().True(ok)
+	s.InDelta(0.2575, ci.Lower, 1e-4)
+	s.InDelta(0.6833, ci.Upper, 1e-4)
+	s.InDelta(0.95, ci.Level, 0)
+
+	// Wider at higher confidence, undefined for tiny samples
+	wide, ok := FisherConfidenceInterval(0.5, 50, 0.99)
+	s.Require().True(ok)
+	s.Less(wide.Lower, ci.Lower)
+	s.Greater(wide.Upper, ci.Upper)
+	_, ok = FisherConfidenceInterval(0.5, 3, 0.95)
+	s.False(ok)
+
+	// Perfect correlations stay finite
+	perfect, ok := FisherConfidenceInterval(1, 10, 0.95)
+	s.Require().True(ok)
+	s.False(math.IsNaN(perfect.Lower))
+	s.InDelta(1.0, perfect.Upper, 1e-6)
+}
+
+// TestCorrelationResultIntervals tests that Pearson correlation results carry their interval
+func (s *AnalyzerTestSuite) TestCorrelationResultIntervals() {
+	ce := NewCorrelationEngine(s.analyzer)
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	y := []float64{2, 1, 4, 3, 6, 5, 8, 7}
+
+	result := ce.pearsonResult("Test", "Sub", x, y, interpretMoonCorrelation)
+	corr, pValue := calculatePearsonCorrelation(x, y)
+	s.InDelta(corr, result.Correlation, 0)
+	s.InDelta(PearsonPValue(corr, len(x)), pValue, 1e-12)
+	s.Equal(getSignificanceLevel(pValue), result.Significance)
+	s.Require().NotNil(result.ConfidenceInterval)
+	s.Less(result.ConfidenceInterval.Lower, corr)
+	s.Greater(result.ConfidenceInterval.Upper, corr)
+	s.Contains(formatCorrelationResult(result, 0.05), "95% CI: [")
+
+	// Tiny samples have no interval
+	result = ce.pearsonResult("Test", "Sub", x[:3], y[:3], interpretMoonCorrelation)
+	s.Nil(result.ConfidenceInterval)
+	s.NotContains(formatCorrelationResult(result, 0.05), "CI:")
+}
@@ -0,0 +1,334 @@
+package lucky
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownStrategy indicates a strategy name that is not registered
+var ErrUnknownStrategy = errors.New("unknown strategy")
+
+// ErrInvalidStrategy indicates a strategy that cannot be registered or produced unusable scores
+var ErrInvalidStrategy = errors.New("invalid strategy")
+
+// ErrNothingToPlay indicates a strategy that scored no numbers, having found nothing worth playing in
+// the analyzed history; its sets are skipped
+var ErrNothingToPlay = errors.New("strategy has nothing to play")
+
+// Strategy scores the numbers of the analyzed game for one kind of recommended set.
+// Recommendations take the highest-scoring main numbers and bonus ball, so a strategy
+// only has to rank them; numbers it leaves out are never picked.
+type Strategy interface {
+	// Name identifies the strategy in recommendations and on the command line
+	Name() string
+	// ScoreMain scores main numbers; higher scores are picked first, and no scores at all means the
+	// strategy has nothing to play
+	ScoreMain(a *Analyzer) []ScoredNumber
+	// ScoreBonus scores bonus balls; nil falls back to scoring them by frequency
+	ScoreBonus(a *Analyzer) []ScoredNumber
+	// Explain describes how the set was chosen
+	Explain(a *Analyzer, set RecommendedSet) string
+}
+
+// TicketScorer is implemented by strategies that also judge whole tickets. Recommendations then weigh
+// the first tickets that meet the constraints, built from the best-ranked numbers first, and play the
+// one with the highest ticket score.
+type TicketScorer interface {
+	// ScoreTicket scores a ticket's main numbers; higher scores are played first
+	ScoreTicket(a *Analyzer, numbers []int) float64
+}
+
+// StrategyRegistry holds the strategies available to an analyzer in registration order
+type StrategyRegistry struct {
+	strategies []Strategy
+}
+
+// NewStrategyRegistry returns a registry holding the built-in strategies
+func NewStrategyRegistry() *StrategyRegistry {
+	return &StrategyRegistry{strategies: builtinStrategies()}
+}
+
+// Register adds a strategy; its name must be new to the registry
+func (r *StrategyRegistry) Register(strategy Strategy) error {
+	if strategy == nil || strings.TrimSpace(strategy.Name()) == "" {
+		return fmt.Errorf("%w: strategy must have a name", ErrInvalidStrategy)
+	}
+	if _, err := r.Lookup(strategy.Name()); err == nil {
+		return fmt.Errorf("%w: %q is already registered", ErrInvalidStrategy, strategy.Name())
+	}
+	r.strategies = append(r.strategies, strategy)
+	return nil
+}
+
+// Lookup returns the registered strategy with the given name
+func (r *StrategyRegistry) Lookup(name string) (Strategy, error) {
+	for _, strategy := range r.strategies {
+		if strategy.Name() == name {
+			return strategy, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q (available: %s)", ErrUnknownStrategy, name, strings.Join(r.Names(), ", "))
+}
+
+// Names returns the registered strategy names in registration order
+func (r *StrategyRegistry) Names() []string {
+	names := make([]string, len(r.strategies))
+	for i, strategy := range r.strategies {
+		names[i] = strategy.Name()
+	}
+	return names
+}
+
+// Select returns the named strategies in the given order, or every registered strategy when names is empty
+func (r *StrategyRegistry) Select(names []string) ([]Strategy, error) {
+	if len(names) == 0 {
+		return append([]Strategy(nil), r.strategies...), nil
+	}
+	selected := make([]Strategy, len(names))
+	for i, name := range names {
+		strategy, err := r.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		selected[i] = strategy
+	}
+	return selected, nil
+}
+
+// Strategies returns the analyzer's strategy registry, where library users can register their own
+func (a *Analyzer) Strategies() *StrategyRegistry {
+	if a.strategies == nil {
+		a.strategies = NewStrategyRegistry()
+	}
+	return a.strategies
+}
+
+// selectedStrategies returns the strategies named in the configuration, or all of them
+func (a *Analyzer) selectedStrategies() ([]Strategy, error) {
+	var names []string
+	if a.config != nil {
+		names = a.config.Strategies
+	}
+	return a.Strategies().Select(names)
+}
+
+// generateSet builds a recommended set from a strategy's scores, taking the top picks or, when a
+// sampling temperature is configured, score-weighted random picks drawn from rng. When taking top
+// picks, numbers in avoid were picked by earlier sets of the same strategy and are only used once
+// the fresh numbers run out. Configured ticket constraints must hold for the main numbers, and a
+// TicketScorer strategy picks the best-scored of the tickets that meet them.
+func (a *Analyzer) generateSet(strategy Strategy, avoid map[int]bool, rng *rand.Rand) (RecommendedSet, error) {
+	game := a.spec()
+	set := RecommendedSet{Strategy: strategy.Name()}
+	if a.samplingTemperature() > 0 {
+		avoid = nil // Sampled sets already differ
+	}
+
+	scores := strategy.ScoreMain(a)
+	if len(scores) == 0 {
+		return RecommendedSet{}, fmt.Errorf("%w: %s scored no numbers", ErrNothingToPlay, strategy.Name())
+	}
+	ranked := a.orderScores(scores, rng)
+	constraints := a.constraints()
+	scorer, scoresTickets := strategy.(TicketScorer)
+	if !constraints.IsZero() || scoresTickets {
+		var scoreTicket func(numbers []int) float64
+		if scoresTickets {
+			scoreTicket = func(numbers []int) float64 { return scorer.ScoreTicket(a, numbers) }
+		}
+		if constraints == nil {
+			constraints = &TicketConstraints{}
+		}
+		numbers, err := constraints.pickConstrained(game, ranked, avoid, a.pastDrawingIndex(), scoreTicket)
+		if err != nil {
+			return RecommendedSet{}, fmt.Errorf("%s: %w", strategy.Name(), err)
+		}
+		set.Numbers = numbers
+	} else {
+		set.Numbers = pickScored(ranked, game.MainPicks, game.ValidMain, avoid)
+	}
+	if len(set.Numbers) < game.MainPicks {
+		return RecommendedSet{}, fmt.Errorf("%w: %s scored %d playable numbers, %s needs %d",
+			ErrInvalidStrategy, strategy.Name(), len(set.Numbers), game.Name, game.MainPicks)
+	}
+	sort.Ints(set.Numbers)
+
+	if game.HasBonus() {
+		bonusScores := strategy.ScoreBonus(a)
+		if len(bonusScores) == 0 {
+			bonusScores = a.scoreLuckyBalls()
+		}
+		if bonus := pickScored(a.orderScores(bonusScores, rng), 1, game.ValidBonus, nil); len(bonus) > 0 {
+			set.LuckyBall = bonus[0]
+		}
+	}
+
+	set.Value = a.ticketValue()
+	set.Explanation = strategy.Explain(a, set)
+	return set, nil
+}
+
+// rankScores sorts scored numbers best first, breaking ties by the lower number so picks are repeatable
+func rankScores(scored []ScoredNumber) []ScoredNumber {
+	ranked := append([]ScoredNumber(nil), scored...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Number < ranked[j].Number
+	})
+	return ranked
+}
+
+// pickScored takes up to count distinct valid numbers from a ranking, preferring numbers not in avoid
+func pickScored(ranked []ScoredNumber, count int, valid func(int) bool, avoid map[int]bool) []int {
+	picked := make([]int, 0, count)
+	used := make(map[int]bool, count)
+	for _, fresh := range []bool{true, false} {
+		for _, sn := range ranked {
+			if len(picked) == count {
+				return picked
+			}
+			if used[sn.Number] || !valid(sn.Number) || avoid[sn.Number] == fresh {
+				continue
+			}
+			picked = append(picked, sn.Number)
+			used[sn.Number] = true
+		}
+	}
+	return picked
+}
+
+// builtinStrategy is a strategy that scores each number independently
+type builtinStrategy struct {
+	name        string
+	explanation string
+	score       func(a *Analyzer, num int, info *NumberInfo) (float64, []string)
+	bonusScore  func(a *Analyzer, num int, info *NumberInfo) (float64, []string) // Nil scores by frequency
+}
+
+// Name returns the strategy name
+func (s *builtinStrategy) Name() string {
+	return s.name
+}
+
+// ScoreMain scores every main number with the strategy's scoring function
+func (s *builtinStrategy) ScoreMain(a *Analyzer) []ScoredNumber {
+	scored := make([]ScoredNumber, 0, len(a.mainNumbers))
+	for num, info := range a.mainNumbers {
+		score, factors := s.score(a, num, info)
+		scored = append(scored, ScoredNumber{Number: num, Score: score, Factors: factors})
+	}
+	return rankScores(scored)
+}
+
+// ScoreBonus scores every bonus ball with the strategy's bonus scoring function
+func (s *builtinStrategy) ScoreBonus(a *Analyzer) []ScoredNumber {
+	if s.bonusScore == nil {
+		return a.scoreLuckyBalls()
+	}
+	scored := make([]ScoredNumber, 0, len(a.luckyBalls))
+	for num, info := range a.luckyBalls {
+		score, factors := s.bonusScore(a, num, info)
+		scored = append(scored, ScoredNumber{Number: num, Score: score, Factors: factors})
+	}
+	return rankScores(scored)
+}
+
+// Explain returns the strategy's fixed explanation
+func (s *builtinStrategy) Explain(_ *Analyzer, _ RecommendedSet) string {
+	return s.explanation
+}
+
+// builtinStrategies returns the built-in strategies in the order sets are generated
+func builtinStrategies() []Strategy {
+	return []Strategy{
+		&builtinStrategy{
+			name:        "balanced",
+			explanation: "Combines hot numbers, overdue numbers, and frequency analysis for a well-rounded selection",
+			score:       scoreBalanced,
+			bonusScore:  scoreBalanced,
+		},
+		&builtinStrategy{
+			name:        "hot",
+			explanation: "Focuses on numbers that have appeared frequently in recent drawings",
+			score:       scoreHot,
+			bonusScore:  scoreHot,
+		},
+		&builtinStrategy{
+			name:        "overdue",
+			explanation: "Selects numbers that haven't appeared for longer than their average gap",
+			score:       scoreOverdue,
+			bonusScore:  scoreOverdueBonus,
+		},
+		newPatternStrategy(),
+		&builtinStrategy{
+			name:        "frequency",
+			explanation: "Selects the most frequently drawn numbers throughout the entire history",
+			score:       scoreFrequency,
+			bonusScore:  scoreFrequency,
+		},
+		newContrarianStrategy(),
+	}
+}
+
+// scoreBalanced mixes frequency, recency, and overdue
+func scoreBalanced(a *Analyzer, _ int, info *NumberInfo) (float64, []string) {
+	factors := []string{}
+	score := float64(info.TotalFrequency) / float64(len(a.drawings)) * 100
+
+	if info.RecentFrequency > 3 {
+		score += float64(info.RecentFrequency) * 10
+		factors = append(factors, fmt.Sprintf("Hot-%d", info.RecentFrequency))
+	}
+
+	if info.AverageGap > 0 && float64(info.CurrentGap) > info.AverageGap*1.3 {
+		overdueRatio := float64(info.CurrentGap) / info.AverageGap
+		score += overdueRatio * 20
+		factors = append(factors, fmt.Sprintf("Overdue-%.1fx", overdueRatio))
+	}
+	return score, factors
+}
+
+// scoreHot focuses on recent frequency
+func scoreHot(_ *Analyzer, _ int, info *NumberInfo) (float64, []string) {
+	factors := []string{}
+	if info.RecentFrequency > 0 {
+		factors = append(factors, fmt.Sprintf("Recent-%d", info.RecentFrequency))
+	}
+	return float64(info.RecentFrequency) * 100, factors
+}
+
+// scoreOverdue focuses on numbers that haven't appeared recently
+func scoreOverdue(_ *Analyzer, _ int, info *NumberInfo) (float64, []string) {
+	if info.AverageGap <= 0 {
+		return 0, []string{}
+	}
+	overdueRatio := float64(info.CurrentGap) / info.AverageGap
+	return overdueRatio * 100, []string{fmt.Sprintf("Gap-%d-days", info.CurrentGap)}
+}
+
+// scoreOverdueBonus scores bonus balls by how far their current gap runs past their average gap.
+// A ball drawn too rarely to have an average gap is measured against the gap expected by chance,
+// and one never drawn has been waiting for the whole history.
+func scoreOverdueBonus(a *Analyzer, _ int, info *NumberInfo) (float64, []string) {
+	game := a.spec()
+	averageGap := info.AverageGap
+	if averageGap <= 0 {
+		averageGap = float64(game.BonusPoolSize) / float64(max(game.BonusPicks, 1))
+	}
+	currentGap := info.CurrentGap
+	if currentGap < 0 {
+		currentGap = len(a.drawings)
+	}
+	overdueRatio := float64(currentGap) / averageGap
+	return overdueRatio * 100, []string{fmt.Sprintf("Gap-%d-draws", currentGap), fmt.Sprintf("Overdue-%.1fx", overdueRatio)}
+}
+
+// scoreFrequency is pure frequency-based selection
+func scoreFrequency(_ *Analyzer, _ int, info *NumberInfo) (float64, []string) {
+	return float64(info.TotalFrequency), []string{fmt.Sprintf("Freq-%d", info.TotalFrequency)}
+}
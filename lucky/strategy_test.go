@@ -0,0 +1,174 @@
+package lucky
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// evenStrategy is a custom strategy that prefers even numbers, lowest first
+type evenStrategy struct{ name string }
+
+// Name returns the registered name
+func (s evenStrategy) Name() string { return s.name }
+
+// ScoreMain scores only even numbers
+func (s evenStrategy) ScoreMain(a *Analyzer) []ScoredNumber {
+	scored := []ScoredNumber{}
+	for num := range a.MainNumbers() {
+		if num%2 == 0 {
+			scored = append(scored, ScoredNumber{Number: num, Score: 100 - float64(num)})
+		}
+	}
+	return scored
+}
+
+// ScoreBonus prefers an unplayable ball over 4
+func (s evenStrategy) ScoreBonus(_ *Analyzer) []ScoredNumber {
+	return []ScoredNumber{{Number: 99, Score: 10}, {Number: 4, Score: 1}}
+}
+
+// Explain returns a fixed explanation
+func (s evenStrategy) Explain(_ *Analyzer, _ RecommendedSet) string { return "Even numbers only" }
+
+// TestStrategyRegistry tests registering, looking up and selecting strategies
+func (s *AnalyzerTestSuite) TestStrategyRegistry() {
+	registry := NewStrategyRegistry()
+	s.Equal([]string{"balanced", "hot", "overdue", "pattern", "frequency", "contrarian"}, registry.Names())
+
+	s.Require().NoError(registry.Register(evenStrategy{name: "even"}))
+	s.Require().ErrorIs(registry.Register(evenStrategy{name: "hot"}), ErrInvalidStrategy)
+	s.Require().ErrorIs(registry.Register(evenStrategy{}), ErrInvalidStrategy)
+	s.Require().ErrorIs(registry.Register(nil), ErrInvalidStrategy)
+	s.Len(registry.Names(), 7)
+
+	selected, err := registry.Select([]string{"even", "hot"})
+	s.Require().NoError(err)
+	s.Require().Len(selected, 2)
+	s.Equal("even", selected[0].Name())
+
+	all, err := registry.Select(nil)
+	s.Require().NoError(err)
+	s.Len(all, 7)
+
+	_, err = registry.Select([]string{"hot", "mine"})
+	s.Require().ErrorIs(err, ErrUnknownStrategy)
+	s.Contains(err.Error(), "available: balanced")
+}
+
+// TestCustomStrategyRecommendations tests generating sets from a registered strategy
+func (s *AnalyzerTestSuite) TestCustomStrategyRecommendations() {
+	ctx := context.Background()
+	s.Require().NoError(s.analyzer.Strategies().Register(evenStrategy{name: "even"}))
+	s.analyzer.config.Strategies = []string{"even"}
+
+	// More sets than strategies: each repeat prefers numbers the earlier sets skipped
+	sets, err := s.analyzer.GenerateRecommendations(ctx, 3)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 3)
+	s.Equal([]int{2, 4, 6, 8, 10}, sets[0].Numbers)
+	s.Equal([]int{12, 14, 16, 18, 20}, sets[1].Numbers)
+	s.Equal("even", sets[2].Strategy)
+	s.Equal(4, sets[0].LuckyBall)     // Unplayable bonus scores are skipped
+	s.Require().NotNil(sets[0].Value) // Every ticket is valued the same, custom strategy or not
+	s.Equal("Even numbers only", sets[0].Explanation)
+
+	// Built-in strategies cycle too, and a repeat differs from the first set
+	s.analyzer.config.Strategies = []string{"hot", "frequency"}
+	sets, err = s.analyzer.GenerateRecommendations(ctx, 4)
+	s.Require().NoError(err)
+	s.Require().Len(sets, 4)
+	s.Equal("frequency", sets[3].Strategy)
+	s.NotEqual(sets[0].Numbers, sets[2].Numbers)
+	s.True(sort.IntsAreSorted(sets[2].Numbers))
+
+	s.analyzer.config.Strategies = []string{"mine"}
+	_, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().ErrorIs(err, ErrUnknownStrategy)
+
+	// A strategy that scores too few playable numbers cannot fill a set
+	s.analyzer.config.Strategies = []string{"sparse"}
+	s.Require().NoError(s.analyzer.Strategies().Register(sparseStrategy{}))
+	_, err = s.analyzer.GenerateRecommendations(ctx, 1)
+	s.Require().ErrorIs(err, ErrInvalidStrategy)
+}
+
+// sparseStrategy scores fewer numbers than a ticket needs
+type sparseStrategy struct{ evenStrategy }
+
+// Name returns the registered name
+func (sparseStrategy) Name() string { return "sparse" }
+
+// ScoreMain scores two playable numbers and one out of range
+func (sparseStrategy) ScoreMain(_ *Analyzer) []ScoredNumber {
+	return []ScoredNumber{{Number: 1, Score: 1}, {Number: 2, Score: 1}, {Number: 200, Score: 5}}
+}
+
+// TestPickScored tests taking distinct, valid, preferably fresh numbers from a ranking
+func (s *AnalyzerTestSuite) TestPickScored() {
+	ranked := rankScores([]ScoredNumber{{Number: 3, Score: 1}, {Number: 1, Score: 5}, {Number: 2, Score: 5}, {Number: 9, Score: 9}})
+	s.Equal([]int{9, 1, 2, 3}, []int{ranked[0].Number, ranked[1].Number, ranked[2].Number, ranked[3].Number})
+
+	valid := func(num int) bool { return num < 9 }
+	s.Equal([]int{1, 2}, pickScored(ranked, 2, valid, nil))
+	s.Equal([]int{2, 3, 1}, pickScored(ranked, 3, valid, map[int]bool{1: true}))
+	s.Equal([]int{1, 2, 3}, pickScored(ranked, 5, valid, nil))
+}
+
+// TestStrategyBonusScoring tests that each built-in strategy picks its own bonus ball from parsed drawings
+func (s *AnalyzerTestSuite) TestStrategyBonusScoring() {
+	// Lucky balls by drawing, newest first: 2 is hot, 3 the most frequent, 1 regular until 65 drawings
+	// ago, 4 drawn once 60 drawings ago, and 5 never drawn
+	balls := make([]int, 100)
+	filler := 0
+	for idx := range balls {
+		switch {
+		case idx < 5:
+			balls[idx] = 2
+		case idx >= 10 && idx < 60 && idx%2 == 0:
+			balls[idx] = 3
+		case idx == 60:
+			balls[idx] = 4
+		case idx > 60 && idx%5 == 0:
+			balls[idx] = 1
+		default:
+			balls[idx] = 7 + filler%12 // Balls 7-18 fill the other drawings in turn
+			filler++
+		}
+	}
+	var csv strings.Builder
+	csv.WriteString("Date,Number 1,Number 2,Number 3,Number 4,Number 5,Lucky Ball\n")
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for idx := len(balls) - 1; idx >= 0; idx-- { // Oldest first, as parsing reverses the rows
+		_, _ = fmt.Fprintf(&csv, "%s,10,20,30,40,48,%d\n", start.AddDate(0, 0, -idx).Format("01/02/2006"), balls[idx])
+	}
+	analyzer, err := NewAnalyzerFromReader(context.Background(), strings.NewReader(csv.String()),
+		&AnalysisConfig{RecentWindow: 10, ConfidenceLevel: 0.95})
+	s.Require().NoError(err)
+
+	s.Equal(65, analyzer.luckyBalls[1].CurrentGap)
+	s.InDelta(5.0, analyzer.luckyBalls[1].AverageGap, 1e-9)
+	s.Equal(0, analyzer.luckyBalls[2].CurrentGap)
+	s.Equal(-1, analyzer.luckyBalls[5].CurrentGap)
+
+	top := func(name string) int {
+		strategy, err := analyzer.Strategies().Lookup(name)
+		s.Require().NoError(err)
+		return rankScores(strategy.ScoreBonus(analyzer))[0].Number
+	}
+	s.Equal(2, top("hot"))
+	s.Equal(3, top("frequency"))
+	s.Equal(1, top("overdue")) // Waiting 65 drawings against an average gap of 5
+	s.Equal(1, top("balanced"))
+
+	// Overdue gap analysis falls back to the gap expected by chance, and a ball never drawn has waited
+	// for the whole history
+	score, factors := scoreOverdueBonus(analyzer, 4, analyzer.luckyBalls[4])
+	s.InDelta(60.0/18*100, score, 1e-9)
+	s.Contains(factors, "Gap-60-draws")
+	score, factors = scoreOverdueBonus(analyzer, 5, analyzer.luckyBalls[5])
+	s.InDelta(100.0/18*100, score, 1e-9)
+	s.Contains(factors, "Gap-100-draws")
+}
@@ -0,0 +1,251 @@
+package lucky
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidTicket indicates a ticket that cannot be played in the game
+var ErrInvalidTicket = errors.New("invalid ticket")
+
+// minReportedMatches is the fewest main numbers a drawing must share with a ticket to be reported
+const minReportedMatches = 2
+
+// TicketHit is a drawing a ticket matched well enough to report
+type TicketHit struct {
+	Date       time.Time  `json:"date"`
+	Numbers    []int      `json:"numbers"`    // Main numbers drawn
+	LuckyBall  int        `json:"lucky_ball"` // Bonus ball drawn
+	Matched    []int      `json:"matched"`    // Ticket numbers that were drawn, ascending
+	BonusMatch bool       `json:"bonus_match"`
+	Tier       *PrizeTier `json:"tier,omitempty"` // Nil when the match paid nothing or prizes are unknown
+	CashValue  float64    `json:"cash_value"`
+}
+
+// TicketCheck is the lifetime record of playing one ticket in every drawing of the history
+type TicketCheck struct {
+	Ticket     Ticket         `json:"ticket"`
+	Drawings   int            `json:"drawings"`
+	Hits       []TicketHit    `json:"hits"`        // Drawings matching 2+ numbers or the bonus ball, most recent first
+	TierCounts map[string]int `json:"tier_counts"` // Wins by prize tier name
+	Spent      float64        `json:"spent"`       // Ticket price times drawings; 0 when prizes are unknown
+	Won        float64        `json:"won"`         // Nominal cash value of every prize
+	Net        float64        `json:"net"`
+}
+
+// ParseTicket reads a ticket such as "05-12-23-34-45+07" or "5,12,23,34,45,7": the game's main
+// numbers followed by the bonus ball, separated by any non-digit characters
+func ParseTicket(game *GameSpec, text string) (Ticket, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	want := game.MainPicks
+	if game.HasBonus() {
+		want++
+	}
+	if len(fields) != want {
+		return Ticket{}, fmt.Errorf("%w: %q has %d numbers, %s needs %d", ErrInvalidTicket, text, len(fields), game.Name, want)
+	}
+
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		num, err := strconv.Atoi(field)
+		if err != nil {
+			return Ticket{}, fmt.Errorf("%w: %q: %w", ErrInvalidTicket, text, err)
+		}
+		numbers[i] = num
+	}
+	ticket := Ticket{Numbers: numbers[:game.MainPicks]}
+	if game.HasBonus() {
+		ticket.LuckyBall = numbers[game.MainPicks]
+	}
+	return ticket, ValidateTicket(game, ticket)
+}
+
+// ReadTickets reads one ticket per CSV row, skipping blank rows and a header row
+func ReadTickets(game *GameSpec, r io.Reader) ([]Ticket, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tickets: %w", err)
+	}
+
+	tickets := make([]Ticket, 0, len(records))
+	for i, record := range records {
+		line := strings.TrimSpace(strings.Join(record, ","))
+		if line == "" || (i == 0 && !unicode.IsDigit(rune(line[0]))) {
+			continue
+		}
+		ticket, parseErr := ParseTicket(game, line)
+		if parseErr != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, parseErr)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// ValidateTicket checks that every number of a ticket can be drawn in the game
+func ValidateTicket(game *GameSpec, ticket Ticket) error {
+	if len(ticket.Numbers) != game.MainPicks {
+		return fmt.Errorf("%w: %d main numbers, %s needs %d", ErrInvalidTicket, len(ticket.Numbers), game.Name, game.MainPicks)
+	}
+	seen := make(map[int]bool, len(ticket.Numbers))
+	for _, num := range ticket.Numbers {
+		if !game.ValidMain(num) {
+			return fmt.Errorf("%w: %d is outside %d-%d", ErrInvalidTicket, num, game.MinNumber, game.MaxNumber())
+		}
+		if seen[num] && !game.AllowRepeats {
+			return fmt.Errorf("%w: %d appears twice", ErrInvalidTicket, num)
+		}
+		seen[num] = true
+	}
+	if game.HasBonus() && !game.ValidBonus(ticket.LuckyBall) {
+		return fmt.Errorf("%w: %s %d is outside 1-%d", ErrInvalidTicket, game.BonusName, ticket.LuckyBall, game.BonusPoolSize)
+	}
+	return nil
+}
+
+// CheckTicket plays a ticket in every drawing of the history and reports the drawings it matched
+func (a *Analyzer) CheckTicket(ticket Ticket) (*TicketCheck, error) {
+	game := a.spec()
+	if err := ValidateTicket(game, ticket); err != nil {
+		return nil, err
+	}
+	prizes, _ := a.PrizeTable() // Nil for games without known prizes
+
+	check := &TicketCheck{
+		Ticket:     ticket,
+		Drawings:   len(a.drawings),
+		Hits:       []TicketHit{},
+		TierCounts: make(map[string]int),
+	}
+	for _, drawing := range a.drawings {
+		matched := matchedNumbers(ticket.Numbers, drawing.Numbers)
+		bonusMatch := game.HasBonus() && ticket.LuckyBall == drawing.LuckyBall
+		if len(matched) < minReportedMatches && !bonusMatch {
+			continue
+		}
+
+		hit := TicketHit{
+			Date:       drawing.Date,
+			Numbers:    drawing.Numbers,
+			LuckyBall:  drawing.LuckyBall,
+			Matched:    matched,
+			BonusMatch: bonusMatch,
+		}
+		if prizes != nil {
+			won := prizes.Evaluate(ticket, drawing)
+			hit.Tier, hit.CashValue = won.Tier, won.CashValue
+			if won.Tier != nil {
+				check.TierCounts[won.Tier.Name]++
+				check.Won += won.CashValue
+			}
+		}
+		check.Hits = append(check.Hits, hit)
+	}
+
+	if prizes != nil {
+		check.Spent = prizes.TicketPrice * float64(check.Drawings)
+	}
+	check.Net = check.Won - check.Spent
+	return check, nil
+}
+
+// matchedNumbers returns the picked numbers that were drawn, ascending, matching each drawn number at most once
+func matchedNumbers(picked, drawn []int) []int {
+	remaining := make(map[int]int, len(drawn))
+	for _, num := range drawn {
+		remaining[num]++
+	}
+	matched := make([]int, 0, len(picked))
+	for _, num := range picked {
+		if remaining[num] > 0 {
+			remaining[num]--
+			matched = append(matched, num)
+		}
+	}
+	sort.Ints(matched)
+	return matched
+}
+
+// WriteTicketChecks writes ticket checks as console tables
+func WriteTicketChecks(w io.Writer, game GameSpec, checks []*TicketCheck) error {
+	c := &consoleWriter{w: w}
+	for _, check := range checks {
+		c.section("🎟️  TICKET " + formatTicket(game, check.Ticket))
+		if len(check.Hits) == 0 {
+			c.printf("No drawing matched %d+ numbers", minReportedMatches)
+			if game.HasBonus() {
+				c.printf(" or the %s", game.BonusName)
+			}
+			c.println(".")
+		} else {
+			c.printf("%-10s  %-20s  %-18s  %s\n", "Date", "Drawn", "Matched", "Prize")
+		}
+		for _, hit := range check.Hits {
+			matched := make([]string, 0, len(hit.Matched)+1)
+			for _, num := range hit.Matched {
+				matched = append(matched, fmt.Sprintf("%02d", num))
+			}
+			if hit.BonusMatch {
+				matched = append(matched, "+"+bonusAbbreviation(game))
+			}
+			prize := "-"
+			if hit.Tier != nil {
+				prize = fmt.Sprintf("%s %s", hit.Tier.Name, hit.Tier.Prize)
+			}
+			c.printf("%-10s  %-20s  %-18s  %s\n", hit.Date.Format("01/02/2006"),
+				formatTicket(game, Ticket{Numbers: hit.Numbers, LuckyBall: hit.LuckyBall}), strings.Join(matched, " "), prize)
+		}
+
+		c.printf("\nLifetime: %d drawings, %d reported", check.Drawings, len(check.Hits))
+		if len(check.TierCounts) > 0 {
+			names := make([]string, 0, len(check.TierCounts))
+			for name := range check.TierCounts {
+				names = append(names, name)
+			}
+			sort.Slice(names, func(i, j int) bool { return names[i] > names[j] })
+			wins := make([]string, len(names))
+			for i, name := range names {
+				wins[i] = fmt.Sprintf("%s ×%d", name, check.TierCounts[name])
+			}
+			c.printf(" | Wins: %s", strings.Join(wins, ", "))
+		}
+		c.println()
+		if check.Spent > 0 {
+			c.printf("Playing every draw: cost $%.2f | won $%.2f | net $%.2f\n", check.Spent, check.Won, check.Net)
+		} else {
+			c.printf("No prize table for %s, so winnings are not valued.\n", game.Name)
+		}
+	}
+	return c.err
+}
+
+// formatTicket formats a ticket as dash-separated numbers followed by its bonus ball
+func formatTicket(game GameSpec, ticket Ticket) string {
+	parts := make([]string, len(ticket.Numbers))
+	for i, num := range ticket.Numbers {
+		parts[i] = fmt.Sprintf("%02d", num)
+	}
+	text := strings.Join(parts, "-")
+	if game.HasBonus() {
+		text += fmt.Sprintf(" %s %02d", bonusAbbreviation(game), ticket.LuckyBall)
+	}
+	return text
+}
+
+// bonusAbbreviation returns the initials of the bonus ball name, e.g. "LB" for Lucky Ball
+func bonusAbbreviation(game GameSpec) string {
+	var initials strings.Builder
+	for _, word := range strings.Fields(game.BonusName) {
+		initials.WriteRune(unicode.ToUpper([]rune(word)[0]))
+	}
+	return initials.String()
+}
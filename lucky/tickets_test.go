@@ -0,0 +1,93 @@
+package lucky
+
+import (
+	"bytes"
+	"strings"
+)
+
+// TestParseTicket tests reading tickets in the accepted formats
+func (s *AnalyzerTestSuite) TestParseTicket() {
+	game := luckyForLifeSpec()
+	for _, text := range []string{"05-12-23-34-45+07", "5,12,23,34,45,7", " 5 12 23 34 45 7 "} {
+		ticket, err := ParseTicket(game, text)
+		s.Require().NoError(err, text)
+		s.Equal(Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7}, ticket, text)
+	}
+
+	for _, text := range []string{"5-12-23-34+7", "5-12-23-34-45-46+7", "5-12-23-34-49+7", "5-5-23-34-45+7", "5-12-23-34-45+19", ""} {
+		_, err := ParseTicket(game, text)
+		s.Require().ErrorIs(err, ErrInvalidTicket, text)
+	}
+
+	// Digit games have no bonus ball and allow repeats
+	pick3, err := LookupGameSpec(GamePick3)
+	s.Require().NoError(err)
+	ticket, err := ParseTicket(pick3, "7-7-0")
+	s.Require().NoError(err)
+	s.Equal([]int{7, 7, 0}, ticket.Numbers)
+}
+
+// TestReadTickets tests reading tickets from CSV with a header and blank rows
+func (s *AnalyzerTestSuite) TestReadTickets() {
+	game := luckyForLifeSpec()
+	tickets, err := ReadTickets(game, strings.NewReader("Number 1,Number 2,Number 3,Number 4,Number 5,Lucky Ball\n5,12,23,34,45,7\n\n1,2,3,4,5,6\n"))
+	s.Require().NoError(err)
+	s.Require().Len(tickets, 2)
+	s.Equal(6, tickets[1].LuckyBall)
+
+	_, err = ReadTickets(game, strings.NewReader("5,12,23,34,45,7\n5,12,23\n"))
+	s.Require().ErrorIs(err, ErrInvalidTicket)
+	s.Contains(err.Error(), "line 2")
+}
+
+// TestCheckTicket tests playing a ticket in every drawing of the history
+func (s *AnalyzerTestSuite) TestCheckTicket() {
+	// The fixture's 01/15/2024 drawing is 5-12-23-34-45 with Lucky Ball 7
+	check, err := s.analyzer.CheckTicket(Ticket{Numbers: []int{5, 12, 23, 34, 45}, LuckyBall: 7})
+	s.Require().NoError(err)
+	s.Equal(len(s.analyzer.Drawings()), check.Drawings)
+	s.InDelta(2.0*float64(check.Drawings), check.Spent, 0)
+	s.Equal(1, check.TierCounts["5+LB"])
+	s.InDelta(check.Won-check.Spent, check.Net, 1e-9)
+
+	won := 0.0
+	for _, hit := range check.Hits {
+		s.True(len(hit.Matched) >= minReportedMatches || hit.BonusMatch, hit.Date)
+		won += hit.CashValue
+	}
+	s.InDelta(check.Won, won, 1e-9)
+
+	// 01/09/2024 drew 5-18-23-35-42 with Lucky Ball 7: two numbers and the ball
+	var found bool
+	for _, hit := range check.Hits {
+		if hit.Date.Format(dateFormatISO) == "2024-01-09" {
+			found = true
+			s.Equal([]int{5, 23}, hit.Matched)
+			s.True(hit.BonusMatch)
+			s.Require().NotNil(hit.Tier)
+			s.Equal("2+LB", hit.Tier.Name)
+		}
+	}
+	s.True(found)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteTicketChecks(&buf, s.analyzer.Game(), []*TicketCheck{check}))
+	s.Contains(buf.String(), "TICKET 05-12-23-34-45 LB 07")
+	s.Contains(buf.String(), "05 23 +LB")
+	s.Contains(buf.String(), "5+LB ×1")
+
+	_, err = s.analyzer.CheckTicket(Ticket{Numbers: []int{1, 2, 3}, LuckyBall: 7})
+	s.Require().ErrorIs(err, ErrInvalidTicket)
+}
+
+// TestCheckTicketNoMatches tests a ticket that never matched enough to report
+func (s *AnalyzerTestSuite) TestCheckTicketNoMatches() {
+	check, err := s.analyzer.CheckTicket(Ticket{Numbers: []int{1, 4, 6, 8, 9}, LuckyBall: 18})
+	s.Require().NoError(err)
+	s.Empty(check.Hits)
+	s.Zero(check.Won)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteTicketChecks(&buf, s.analyzer.Game(), []*TicketCheck{check}))
+	s.Contains(buf.String(), "No drawing matched 2+ numbers or the Lucky Ball.")
+}
@@ -0,0 +1,437 @@
+package lucky
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// ErrInvalidWheel indicates a wheel that cannot be built from the given pool and guarantee
+var ErrInvalidWheel = errors.New("invalid wheel")
+
+// Wheel types
+const (
+	WheelFull        = "full"        // Every combination of the pool
+	WheelAbbreviated = "abbreviated" // A covering subset of the combinations with a k-if-m guarantee
+	WheelKey         = "key"         // Key numbers on every ticket, the rest of the pool abbreviated
+)
+
+// Wheel pool sources
+const (
+	WheelPoolTop     = "top"     // Most frequent numbers overall
+	WheelPoolRecent  = "recent"  // Most frequent numbers in the recent window
+	WheelPoolOverdue = "overdue" // Most overdue numbers
+)
+
+const (
+	// MaxWheelPool is the largest pool the covering search accepts
+	MaxWheelPool = 20
+
+	// maxFullWheelTickets caps the size of a full wheel
+	maxFullWheelTickets = 2000
+)
+
+// WheelConfig describes the wheel to build
+type WheelConfig struct {
+	Type      string `json:"type"`       // WheelFull, WheelAbbreviated or WheelKey; empty means abbreviated
+	Pool      []int  `json:"pool"`       // Numbers to wheel, best first; keys may be included
+	Keys      []int  `json:"keys"`       // Key numbers played on every ticket of a key wheel
+	Guarantee int    `json:"guarantee"`  // k of "k-if-m": zero picks the type's default
+	Condition int    `json:"condition"`  // m of "k-if-m": zero picks the type's default
+	LuckyBall int    `json:"lucky_ball"` // Bonus ball played on every ticket of games with one
+}
+
+// Wheel is a set of tickets covering a pool of numbers. The guarantee is about the pool's non-key
+// numbers: if Condition of them are drawn (with every key number, for a key wheel), at least one
+// ticket matches Guarantee of them.
+type Wheel struct {
+	Game         string   `json:"game"`
+	Type         string   `json:"type"`
+	Keys         []int    `json:"keys"`
+	Pool         []int    `json:"pool"` // Non-key numbers
+	Guarantee    int      `json:"guarantee"`
+	Condition    int      `json:"condition"`
+	Tickets      []Ticket `json:"tickets"`
+	Combinations int      `json:"combinations"` // Condition-number combinations of the pool
+	Covered      int      `json:"covered"`      // Combinations verified to be matched in at least Guarantee numbers
+	Proven       bool     `json:"proven"`       // Every combination is covered
+	Cost         float64  `json:"cost"`         // Price of one drawing's tickets; zero without a prize table
+}
+
+// WheelSummary is a wheel without its tickets
+type WheelSummary struct {
+	Game         string  `json:"game"`
+	Type         string  `json:"type"`
+	Keys         []int   `json:"keys"`
+	Pool         []int   `json:"pool"`
+	Guarantee    int     `json:"guarantee"`
+	Condition    int     `json:"condition"`
+	Tickets      int     `json:"tickets"`
+	Combinations int     `json:"combinations"`
+	Covered      int     `json:"covered"`
+	Proven       bool    `json:"proven"`
+	Cost         float64 `json:"cost"`
+}
+
+// WheelPool returns up to size main numbers from source: WheelPoolTop, WheelPoolRecent or WheelPoolOverdue
+func (a *Analyzer) WheelPool(source string, size int) ([]int, error) {
+	var infos []*NumberInfo
+	switch source {
+	case WheelPoolTop, "":
+		infos = a.GetTopNumbers(size, false)
+	case WheelPoolRecent:
+		infos = a.GetTopNumbers(size, true)
+	case WheelPoolOverdue:
+		infos = a.GetOverdueNumbers(size)
+	default:
+		return nil, fmt.Errorf("%w: unknown pool source %q (available: %s, %s, %s)", ErrInvalidWheel, source,
+			WheelPoolTop, WheelPoolRecent, WheelPoolOverdue)
+	}
+	pool := make([]int, len(infos))
+	for i, info := range infos {
+		pool[i] = info.Number
+	}
+	return pool, nil
+}
+
+// Wheel builds a wheel for the analyzed game. A zero LuckyBall plays the top bonus ball of the first
+// configured strategy, the ball its recommended set takes, and the cost comes from the game's prize
+// table when it has one.
+func (a *Analyzer) Wheel(config WheelConfig) (*Wheel, error) {
+	game := a.spec()
+	if game.HasBonus() && config.LuckyBall == 0 {
+		strategies, err := a.selectedStrategies()
+		if err != nil {
+			return nil, err
+		}
+		var bonusScores []ScoredNumber
+		if len(strategies) > 0 {
+			bonusScores = strategies[0].ScoreBonus(a)
+		}
+		if len(bonusScores) == 0 {
+			bonusScores = a.scoreLuckyBalls()
+		}
+		if bonus := pickScored(rankScores(bonusScores), 1, game.ValidBonus, nil); len(bonus) > 0 {
+			config.LuckyBall = bonus[0]
+		}
+	}
+	wheel, err := BuildWheel(game, config)
+	if err != nil {
+		return nil, err
+	}
+	if prizes, prizeErr := a.PrizeTable(); prizeErr == nil {
+		wheel.Cost = prizes.TicketPrice * float64(len(wheel.Tickets))
+	}
+	return wheel, nil
+}
+
+// BuildWheel builds a full, abbreviated or key wheel for game and verifies its guarantee against
+// every combination of the pool. Abbreviated wheels come from a greedy covering-design search:
+// repeatedly take the ticket that covers the most uncovered combinations, preferring the pool's
+// first numbers on ties, then drop tickets made redundant by later ones.
+func BuildWheel(game *GameSpec, config WheelConfig) (*Wheel, error) {
+	if config.Type == "" {
+		config.Type = WheelAbbreviated
+	}
+	keys, pool, err := wheelNumbers(game, config)
+	if err != nil {
+		return nil, err
+	}
+	size := game.MainPicks - len(keys) // Pool numbers on each ticket
+	guarantee, condition := config.Guarantee, config.Condition
+	if guarantee == 0 && condition == 0 {
+		guarantee, condition = defaultWheelGuarantee(config.Type, size)
+	}
+	switch {
+	case guarantee < 1 || guarantee > condition:
+		return nil, fmt.Errorf("%w: guarantee %d-if-%d must match between 1 and %d numbers", ErrInvalidWheel, guarantee, condition, condition)
+	case condition > size:
+		return nil, fmt.Errorf("%w: a ticket holds only %d pool numbers, so the guarantee cannot be %d-if-%d", ErrInvalidWheel, size, guarantee, condition)
+	case game.HasBonus() && !game.ValidBonus(config.LuckyBall):
+		return nil, fmt.Errorf("%w: %s %d is outside 1-%d", ErrInvalidWheel, game.BonusName, config.LuckyBall, game.BonusPoolSize)
+	}
+
+	var masks []uint32
+	switch config.Type {
+	case WheelFull:
+		if tickets := math.Round(math.Exp(logChoose(len(pool), size))); tickets > maxFullWheelTickets {
+			return nil, fmt.Errorf("%w: a full wheel of %d numbers needs %.0f tickets; use an abbreviated wheel",
+				ErrInvalidWheel, len(pool), tickets)
+		}
+		masks = combinationMasks(len(pool), size)
+	case WheelAbbreviated, WheelKey:
+		masks = coverWheel(len(pool), size, guarantee, condition)
+	}
+
+	wheel := &Wheel{
+		Game:      game.Key,
+		Type:      config.Type,
+		Keys:      keys,
+		Pool:      pool,
+		Guarantee: guarantee,
+		Condition: condition,
+		Tickets:   make([]Ticket, len(masks)),
+	}
+	wheel.Combinations, wheel.Covered = wheelCoverage(masks, len(pool), guarantee, condition)
+	wheel.Proven = wheel.Covered == wheel.Combinations
+	for i, mask := range masks {
+		numbers := append([]int(nil), keys...)
+		for idx, num := range pool {
+			if mask&(1<<idx) != 0 {
+				numbers = append(numbers, num)
+			}
+		}
+		sort.Ints(numbers)
+		wheel.Tickets[i] = Ticket{Numbers: numbers, LuckyBall: config.LuckyBall}
+	}
+	sort.Slice(wheel.Tickets, func(i, j int) bool {
+		return slices.Compare(wheel.Tickets[i].Numbers, wheel.Tickets[j].Numbers) < 0
+	})
+	return wheel, nil
+}
+
+// Summary returns the wheel without its tickets
+func (w *Wheel) Summary() WheelSummary {
+	return WheelSummary{
+		Game:         w.Game,
+		Type:         w.Type,
+		Keys:         w.Keys,
+		Pool:         w.Pool,
+		Guarantee:    w.Guarantee,
+		Condition:    w.Condition,
+		Tickets:      len(w.Tickets),
+		Combinations: w.Combinations,
+		Covered:      w.Covered,
+		Proven:       w.Proven,
+		Cost:         w.Cost,
+	}
+}
+
+// Records returns the wheel as NDJSON records: its summary followed by every ticket
+func (w *Wheel) Records() []Record {
+	records := make([]Record, 0, len(w.Tickets)+1)
+	records = append(records, Record{Type: RecordTypeWheelSummary, Data: w.Summary()})
+	for _, ticket := range w.Tickets {
+		records = append(records, Record{Type: RecordTypeWheelTicket, Data: ticket})
+	}
+	return records
+}
+
+// wheelNumbers validates the key numbers and the pool, returning the keys and the non-key pool numbers
+func wheelNumbers(game *GameSpec, config WheelConfig) ([]int, []int, error) {
+	switch {
+	case game.AllowRepeats:
+		return nil, nil, fmt.Errorf("%w: %s draws digits, which cannot be wheeled", ErrInvalidWheel, game.Name)
+	case config.Type != WheelFull && config.Type != WheelAbbreviated && config.Type != WheelKey:
+		return nil, nil, fmt.Errorf("%w: unknown type %q (available: %s, %s, %s)", ErrInvalidWheel, config.Type,
+			WheelFull, WheelAbbreviated, WheelKey)
+	case config.Type == WheelKey && len(config.Keys) == 0:
+		return nil, nil, fmt.Errorf("%w: a key wheel needs key numbers", ErrInvalidWheel)
+	case config.Type != WheelKey && len(config.Keys) > 0:
+		return nil, nil, fmt.Errorf("%w: key numbers need a %s wheel", ErrInvalidWheel, WheelKey)
+	case len(config.Keys) >= game.MainPicks:
+		return nil, nil, fmt.Errorf("%w: %d key numbers leave no room on a %d-number ticket", ErrInvalidWheel, len(config.Keys), game.MainPicks)
+	}
+
+	seen := make(map[int]bool, len(config.Keys)+len(config.Pool))
+	keys := make([]int, 0, len(config.Keys))
+	for _, num := range config.Keys {
+		if !game.ValidMain(num) || seen[num] {
+			return nil, nil, fmt.Errorf("%w: key number %d is out of range or repeated", ErrInvalidWheel, num)
+		}
+		seen[num] = true
+		keys = append(keys, num)
+	}
+	sort.Ints(keys)
+	isKey := make(map[int]bool, len(keys))
+	for _, num := range keys {
+		isKey[num] = true
+	}
+
+	pool := make([]int, 0, len(config.Pool))
+	for _, num := range config.Pool {
+		switch {
+		case isKey[num]:
+			continue
+		case !game.ValidMain(num) || seen[num]:
+			return nil, nil, fmt.Errorf("%w: pool number %d is out of range or repeated", ErrInvalidWheel, num)
+		}
+		seen[num] = true
+		pool = append(pool, num)
+	}
+	size := game.MainPicks - len(keys)
+	if len(pool) < size || len(pool) > MaxWheelPool {
+		return nil, nil, fmt.Errorf("%w: the pool has %d non-key numbers, needs %d to %d", ErrInvalidWheel, len(pool), size, MaxWheelPool)
+	}
+	return keys, pool, nil
+}
+
+// defaultWheelGuarantee returns the guarantee of a wheel type with size pool numbers per ticket:
+// a full wheel matches every number drawn, an abbreviated one size-2 if size-1 (3-if-4 for five numbers)
+func defaultWheelGuarantee(wheelType string, size int) (int, int) {
+	if wheelType == WheelFull {
+		return size, size
+	}
+	guarantee := max(size-2, 1)
+	return guarantee, max(size-1, guarantee)
+}
+
+// coverWheel greedily picks size-number subsets of an n-number pool until every m-number subset
+// shares at least k numbers with one of them, then drops the picks the rest make redundant
+func coverWheel(n, size, k, m int) []uint32 {
+	candidates := combinationMasks(n, size)
+	targets := combinationMasks(n, m)
+	gains := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		for _, target := range targets {
+			if covers(candidate, target, k) {
+				gains[i]++
+			}
+		}
+	}
+
+	covered := make([]bool, len(targets))
+	var chosen []uint32
+	for remaining := len(targets); remaining > 0; {
+		best := 0
+		for i, gain := range gains {
+			if gain > gains[best] {
+				best = i
+			}
+		}
+		chosen = append(chosen, candidates[best])
+		for j, target := range targets {
+			if covered[j] || !covers(candidates[best], target, k) {
+				continue
+			}
+			covered[j] = true
+			remaining--
+			for i, candidate := range candidates {
+				if covers(candidate, target, k) {
+					gains[i]--
+				}
+			}
+		}
+	}
+
+	// A ticket is redundant when every combination it covers is covered by another ticket too
+	counts := make([]int, len(targets))
+	for _, ticket := range chosen {
+		for j, target := range targets {
+			if covers(ticket, target, k) {
+				counts[j]++
+			}
+		}
+	}
+	kept := chosen[:0]
+	for _, ticket := range chosen {
+		redundant := true
+		for j, target := range targets {
+			if covers(ticket, target, k) && counts[j] < 2 {
+				redundant = false
+				break
+			}
+		}
+		if !redundant {
+			kept = append(kept, ticket)
+			continue
+		}
+		for j, target := range targets {
+			if covers(ticket, target, k) {
+				counts[j]--
+			}
+		}
+	}
+	return kept
+}
+
+// wheelCoverage counts the m-number subsets of an n-number pool and how many of them share at least
+// k numbers with some ticket
+func wheelCoverage(tickets []uint32, n, k, m int) (int, int) {
+	targets := combinationMasks(n, m)
+	covered := 0
+	for _, target := range targets {
+		for _, ticket := range tickets {
+			if covers(ticket, target, k) {
+				covered++
+				break
+			}
+		}
+	}
+	return len(targets), covered
+}
+
+// covers reports whether a ticket shares at least k numbers with a combination
+func covers(ticket, combination uint32, k int) bool {
+	return bits.OnesCount32(ticket&combination) >= k
+}
+
+// combinationMasks returns every size-element subset of n pool positions as bit masks, in
+// lexicographic order of the positions so the pool's first numbers come first
+func combinationMasks(n, size int) []uint32 {
+	var masks []uint32
+	var walk func(start int, mask uint32, left int)
+	walk = func(start int, mask uint32, left int) {
+		if left == 0 {
+			masks = append(masks, mask)
+			return
+		}
+		for i := start; i <= n-left; i++ {
+			walk(i+1, mask|1<<i, left-1)
+		}
+	}
+	walk(0, 0, size)
+	return masks
+}
+
+// WriteWheel writes the wheel's tickets and its verified guarantee
+func WriteWheel(w io.Writer, game GameSpec, wheel *Wheel) error {
+	c := &consoleWriter{w: w}
+	title := "🎡 ABBREVIATED WHEEL"
+	switch wheel.Type {
+	case WheelFull:
+		title = "🎡 FULL WHEEL"
+	case WheelKey:
+		title = "🎡 KEY NUMBER WHEEL"
+	}
+	c.section(title)
+	c.printf("Game: %s\n", game.Name)
+	if len(wheel.Keys) > 0 {
+		c.printf("Key numbers (on every ticket): ")
+		c.numbers(wheel.Keys)
+		c.println()
+	}
+	c.printf("Pool (%d numbers): ", len(wheel.Pool))
+	c.numbers(wheel.Pool)
+	c.println()
+	c.printf("Guarantee: %d-if-%d\n\n", wheel.Guarantee, wheel.Condition)
+
+	for i, ticket := range wheel.Tickets {
+		c.printf("%4d. %s\n", i+1, formatTicket(game, ticket))
+	}
+
+	c.printf("\n%d tickets", len(wheel.Tickets))
+	if wheel.Cost > 0 {
+		c.printf(" ($%.2f per drawing)", wheel.Cost)
+	}
+	if wheel.Type != WheelFull {
+		c.printf(" instead of %.0f for a full wheel", math.Exp(logChoose(len(wheel.Pool), game.MainPicks-len(wheel.Keys))))
+	}
+	c.println()
+	condition := fmt.Sprintf("%d of the %d pool numbers are drawn", wheel.Condition, len(wheel.Pool))
+	if len(wheel.Keys) > 0 {
+		condition = fmt.Sprintf("every key number and %d of the other %d pool numbers are drawn", wheel.Condition, len(wheel.Pool))
+	}
+	if wheel.Proven {
+		c.printf("✓ Proven: checked all %d combinations — if %s, a ticket matches at least %d of them\n",
+			wheel.Combinations, condition, wheel.Guarantee)
+	} else {
+		c.printf("✗ Not proven: only %d of %d combinations are covered\n", wheel.Covered, wheel.Combinations)
+	}
+	c.println("A wheel spreads tickets over your numbers; it does not change the odds of any ticket.")
+	return c.err
+}
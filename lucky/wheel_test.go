@@ -0,0 +1,119 @@
+package lucky
+
+import (
+	"bytes"
+	"context"
+)
+
+// TestBuildWheel tests full, abbreviated and key wheels and their verified guarantees
+func (s *AnalyzerTestSuite) TestBuildWheel() {
+	game := s.analyzer.spec()
+	pool := []int{1, 5, 9, 12, 17, 23, 28, 31, 36, 40, 44, 48}
+
+	wheel, err := BuildWheel(game, WheelConfig{Pool: pool, LuckyBall: 7})
+	s.Require().NoError(err)
+	s.Equal(WheelAbbreviated, wheel.Type)
+	s.Equal([]int{3, 4}, []int{wheel.Guarantee, wheel.Condition})
+	s.Equal(495, wheel.Combinations)
+	s.True(wheel.Proven)
+	s.Less(len(wheel.Tickets), 792/10)
+	for _, ticket := range wheel.Tickets {
+		s.Require().NoError(ValidateTicket(game, ticket))
+		s.Equal(7, ticket.LuckyBall)
+	}
+
+	// Check the guarantee independently: every 4 of the pool share 3 numbers with some ticket
+	for _, mask := range combinationMasks(len(pool), 4) {
+		best := 0
+		for _, ticket := range wheel.Tickets {
+			matched := 0
+			for idx, num := range pool {
+				if mask&(1<<idx) != 0 && countMatches(ticket.Numbers, []int{num}) > 0 {
+					matched++
+				}
+			}
+			best = max(best, matched)
+		}
+		s.GreaterOrEqual(best, 3)
+	}
+
+	wheel, err = BuildWheel(game, WheelConfig{Type: WheelFull, Pool: pool[:7], LuckyBall: 7})
+	s.Require().NoError(err)
+	s.Len(wheel.Tickets, 21)
+	s.Equal([]int{5, 5}, []int{wheel.Guarantee, wheel.Condition})
+	s.True(wheel.Proven)
+
+	wheel, err = BuildWheel(game, WheelConfig{Type: WheelKey, Pool: pool, Keys: []int{12}, Guarantee: 3, Condition: 3, LuckyBall: 7})
+	s.Require().NoError(err)
+	s.Len(wheel.Pool, 11)
+	s.Equal(165, wheel.Combinations)
+	s.True(wheel.Proven)
+	for _, ticket := range wheel.Tickets {
+		s.Contains(ticket.Numbers, 12)
+	}
+
+	large := make([]int, MaxWheelPool+1)
+	for i := range large {
+		large[i] = i + 1
+	}
+	testCases := []struct {
+		name   string
+		config WheelConfig
+	}{
+		{"unknown type", WheelConfig{Type: "partial", Pool: pool, LuckyBall: 7}},
+		{"pool too small", WheelConfig{Pool: pool[:4], LuckyBall: 7}},
+		{"pool too large", WheelConfig{Pool: large, LuckyBall: 7}},
+		{"repeated number", WheelConfig{Pool: append([]int{1}, pool...), LuckyBall: 7}},
+		{"out of range", WheelConfig{Pool: append([]int{49}, pool...), LuckyBall: 7}},
+		{"guarantee above condition", WheelConfig{Pool: pool, Guarantee: 4, Condition: 3, LuckyBall: 7}},
+		{"condition above a ticket", WheelConfig{Pool: pool, Guarantee: 3, Condition: 6, LuckyBall: 7}},
+		{"key wheel without keys", WheelConfig{Type: WheelKey, Pool: pool, LuckyBall: 7}},
+		{"keys on an abbreviated wheel", WheelConfig{Pool: pool, Keys: []int{1}, LuckyBall: 7}},
+		{"full wheel too large", WheelConfig{Type: WheelFull, Pool: append(pool, 2, 3, 4, 6, 7, 8, 10, 11), LuckyBall: 7}},
+		{"missing lucky ball", WheelConfig{Pool: pool}},
+	}
+	for _, tc := range testCases {
+		_, err = BuildWheel(game, tc.config)
+		s.Require().ErrorIs(err, ErrInvalidWheel, "Test case: %s", tc.name)
+	}
+}
+
+// TestAnalyzerWheel tests wheeling the analysis' best numbers with the first strategy's lucky ball
+func (s *AnalyzerTestSuite) TestAnalyzerWheel() {
+	pool, err := s.analyzer.WheelPool(WheelPoolTop, 4)
+	s.Require().NoError(err)
+	s.Equal([]int{23, 5, 12, 34}, pool) // Ties go to the lower number
+	_, err = s.analyzer.WheelPool("lucky", 4)
+	s.Require().ErrorIs(err, ErrInvalidWheel)
+
+	pool, err = s.analyzer.WheelPool(WheelPoolRecent, 8)
+	s.Require().NoError(err)
+	wheel, err := s.analyzer.Wheel(WheelConfig{Pool: pool})
+	s.Require().NoError(err)
+	s.Equal(7, wheel.Tickets[0].LuckyBall)
+	s.InDelta(2*float64(len(wheel.Tickets)), wheel.Cost, 1e-9)
+
+	// The default lucky ball is the one the strategy's recommended set plays
+	for _, name := range []string{"balanced", "hot", "overdue", "frequency", "contrarian"} {
+		s.analyzer.config.Strategies = []string{name}
+		sets, setErr := s.analyzer.GenerateRecommendations(context.Background(), 1)
+		s.Require().NoError(setErr)
+		strategyWheel, wheelErr := s.analyzer.Wheel(WheelConfig{Pool: pool})
+		s.Require().NoError(wheelErr)
+		s.Equal(sets[0].LuckyBall, strategyWheel.Tickets[0].LuckyBall, name)
+	}
+	s.analyzer.config.Strategies = []string{"mine"}
+	_, err = s.analyzer.Wheel(WheelConfig{Pool: pool})
+	s.Require().ErrorIs(err, ErrUnknownStrategy)
+	s.analyzer.config.Strategies = nil
+
+	records := wheel.Records()
+	s.Require().Len(records, len(wheel.Tickets)+1)
+	s.Equal(RecordTypeWheelSummary, records[0].Type)
+	s.Equal(len(wheel.Tickets), records[0].Data.(WheelSummary).Tickets)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WriteWheel(&buf, s.analyzer.Game(), wheel))
+	s.Contains(buf.String(), "ABBREVIATED WHEEL")
+	s.Contains(buf.String(), "✓ Proven: checked all 70 combinations")
+}
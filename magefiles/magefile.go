@@ -4,6 +4,7 @@
 package magefiles
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/magefile/mage/mg"
 	"github.com/magefile/mage/sh"
+
+	"github.com/mrz1836/go-lucky/lucky"
 )
 
 // Variables
@@ -19,6 +22,9 @@ const (
 	binaryPath   = "./bin/go-lucky"
 	coverageFile = "coverage.out"
 	testTimeout  = "30s"
+
+	// quickNumberCount is how many numbers the quick commands list
+	quickNumberCount = 5
 )
 
 // logInfo prints informational messages to stdout
@@ -65,6 +71,39 @@ func runAnalyzerWithOutput(args ...string) (string, error) {
 	return sh.Output(binaryPath, args...)
 }
 
+// Helper function to run the analysis and decode its JSON report
+func analyzerReport() (*lucky.Report, error) {
+	output, err := runAnalyzerWithOutput("analyze", "--mode", "simple", "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+	var report lucky.Report
+	if err = json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("failed to decode analysis report: %w", err)
+	}
+	return &report, nil
+}
+
+// Helper function to format a ticket as zero-padded, dash-separated numbers
+func formatNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, num := range numbers {
+		parts[i] = fmt.Sprintf("%02d", num)
+	}
+	return strings.Join(parts, "-")
+}
+
+// Helper function to print up to limit numbers with their frequency and gap
+func logNumbers(numbers []lucky.NumberInfo, limit int) {
+	for i, info := range numbers {
+		if i >= limit {
+			break
+		}
+		logInfo(fmt.Sprintf("   %2d. Number %2d: %d recent, %d total, %d drawings since last seen",
+			i+1, info.Number, info.RecentFrequency, info.TotalFrequency, info.CurrentGap))
+	}
+}
+
 // TestQuick runs fast unit tests excluding performance tests
 func TestQuick() error {
 	return sh.RunV("go", "test", "-short", "./...")
@@ -102,8 +141,7 @@ func Clean() error {
 
 	// Remove generated files with patterns
 	patterns := []string{
-		"lottery_analysis_*.json",
-		"lottery_analysis_*.csv",
+		"lottery_analysis_*", // JSON exports and CSV bundle directories or archives
 		"test_*.csv",
 		"debug_*.csv",
 		"empty_*.csv",
@@ -168,25 +206,25 @@ func (Analysis) Full() error {
 	logInfo("╚══════════════════════════════════════════════════════════════╝")
 	logInfo("")
 
-	return runAnalyzer("--cosmic")
+	return runAnalyzer("cosmic")
 }
 
 // Simple runs simple analysis summary
 func (Analysis) Simple() error {
 	mg.Deps(Build{}.Dev)
-	return runAnalyzer("--simple")
+	return runAnalyzer("analyze", "--mode", "simple")
 }
 
 // Statistical runs detailed statistical analysis
 func (Analysis) Statistical() error {
 	mg.Deps(Build{}.Dev)
-	return runAnalyzer("--statistical")
+	return runAnalyzer("analyze", "--mode", "statistical")
 }
 
 // Cosmic runs cosmic correlation analysis only
 func (Analysis) Cosmic() error {
 	mg.Deps(Build{}.Dev)
-	return runAnalyzer("--cosmic")
+	return runAnalyzer("cosmic")
 }
 
 // Export Commands
@@ -196,22 +234,22 @@ func (Export) JSON() error {
 	mg.Deps(Build{}.Dev)
 
 	logInfo("📊 Exporting analysis to JSON...")
-	if err := runAnalyzer("--cosmic", "--export-json"); err != nil {
+	if err := runAnalyzer("export", "--format", "json"); err != nil {
 		return err
 	}
 	logInfo("✅ Export complete! Check lottery_analysis_*.json")
 	return nil
 }
 
-// CSV exports analysis data to CSV file
+// CSV exports the analysis as a directory of CSV tables
 func (Export) CSV() error {
 	mg.Deps(Build{}.Dev)
 
-	logInfo("📊 Exporting analysis to CSV...")
-	if err := runAnalyzer("--cosmic", "--export-csv"); err != nil {
+	logInfo("📊 Exporting analysis to CSV bundle...")
+	if err := runAnalyzer("export", "--format", "csv"); err != nil {
 		return err
 	}
-	logInfo("✅ Export complete! Check lottery_analysis_*.csv")
+	logInfo("✅ Export complete! Check lottery_analysis_*/manifest.json")
 	return nil
 }
 
@@ -224,37 +262,26 @@ func (Quick) LuckyPicks() error {
 	logInfo("🎰 Generating Lucky Picks...")
 	logInfo("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	output, err := runAnalyzerWithOutput("--simple")
+	report, err := analyzerReport()
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(output, "\n")
-	printSection := false
-	lineCount := 0
-
-	for _, line := range lines {
-		if strings.Contains(line, "QUICK PICKS:") {
-			printSection = true
-			lineCount = 0
-		}
-		if printSection {
-			logInfo(line)
-			lineCount++
-			if lineCount > 10 {
-				break
-			}
+	for i, rec := range report.Recommendations {
+		line := fmt.Sprintf("   Set %d (%s): %s", i+1, rec.Strategy, formatNumbers(rec.Numbers))
+		if report.Game.BonusName != "" {
+			line += fmt.Sprintf("  %s: %d", report.Game.BonusName, rec.LuckyBall)
 		}
+		logInfo(line)
 	}
 
 	logInfo("")
 	logInfo("🌌 Cosmic Pick:")
-	for _, line := range lines {
-		if strings.Contains(line, "COSMIC PICK:") {
-			logInfo(line)
-			break
-		}
+	cosmic := "   " + formatNumbers(report.CosmicPick)
+	if report.CosmicLuckyBall > 0 {
+		cosmic += fmt.Sprintf("  %s: %d", report.Game.BonusName, report.CosmicLuckyBall)
 	}
+	logInfo(cosmic)
 	logInfo("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	return nil
@@ -265,28 +292,11 @@ func (Quick) HotNumbers() error {
 	mg.Deps(Build{}.Dev)
 
 	logInfo("🔥 Current Hot Numbers:")
-	output, err := runAnalyzerWithOutput("--simple")
+	report, err := analyzerReport()
 	if err != nil {
 		return err
 	}
-
-	lines := strings.Split(output, "\n")
-	printSection := false
-	lineCount := 0
-
-	for _, line := range lines {
-		if strings.Contains(line, "TOP 5 HOT NUMBERS:") {
-			printSection = true
-			lineCount = 0
-		}
-		if printSection {
-			logInfo(line)
-			lineCount++
-			if lineCount > 7 {
-				break
-			}
-		}
-	}
+	logNumbers(report.HotNumbers, quickNumberCount)
 
 	return nil
 }
@@ -296,28 +306,11 @@ func (Quick) Overdue() error {
 	mg.Deps(Build{}.Dev)
 
 	logInfo("⏰ Most Overdue Numbers:")
-	output, err := runAnalyzerWithOutput("--simple")
+	report, err := analyzerReport()
 	if err != nil {
 		return err
 	}
-
-	lines := strings.Split(output, "\n")
-	printSection := false
-	lineCount := 0
-
-	for _, line := range lines {
-		if strings.Contains(line, "TOP 5 OVERDUE:") {
-			printSection = true
-			lineCount = 0
-		}
-		if printSection {
-			logInfo(line)
-			lineCount++
-			if lineCount > 7 {
-				break
-			}
-		}
-	}
+	logNumbers(report.OverdueNumbers, quickNumberCount)
 
 	return nil
 }
@@ -344,17 +337,11 @@ func (Fun) Fortune() error {
 	logInfo("🔮 Your Lottery Fortune:")
 	logInfo("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	output, err := runAnalyzerWithOutput("--simple")
-	if err != nil {
+	report, err := analyzerReport()
+	if err != nil || len(report.CosmicPick) == 0 {
 		logInfo("The stars are silent today...")
 	} else {
-		lines := strings.Split(output, "\n")
-		for _, line := range lines {
-			if strings.Contains(line, "COSMIC PICK:") {
-				logInfo(line)
-				break
-			}
-		}
+		logInfo("COSMIC PICK: " + formatNumbers(report.CosmicPick))
 	}
 
 	logInfo("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")